@@ -20,9 +20,12 @@
 package aemet
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"math"
 	"net/http"
 	"os"
@@ -37,6 +40,8 @@ const (
 
 	maxRetries    = 3
 	baseBackoffMs = 100
+
+	defaultConcurrency = 4
 )
 
 // Config holds the configuration for the AEMET client.
@@ -53,8 +58,33 @@ type Config struct {
 	// If nil, a default client with 30-second timeout will be used.
 	HTTPClient *http.Client
 
-	// Logger specifies a custom logger for the client.
-	// If nil, a default logger writing to stderr will be used.
+	// Concurrency is the maximum number of requests batch methods such as
+	// GetForecasts run at once. If zero, 4 is used.
+	Concurrency int
+
+	// RateLimit is the maximum number of requests per minute the client sends
+	// to AEMET, shared by every call, including concurrent ones. Both requests
+	// of the two-step fetch count. If zero, requests are not limited.
+	RateLimit int
+
+	// Hooks are invoked, in order, around every call the client makes to the API.
+	Hooks []Hook
+
+	// Cache stores the last payload of each request. When set, calls that fail
+	// after every retry return the cached data instead, marked as stale.
+	Cache Cache
+
+	// Offline makes the client serve every call from Cache without touching
+	// the network. Calls without cached data fail with ErrNotCached.
+	Offline bool
+
+	// SlogLogger receives the client's structured log events: requests at debug
+	// level, retries at info, failed attempts at warn and decoding failures at error.
+	// If nil, and Logger is nil too, the client logs nothing.
+	SlogLogger *slog.Logger
+
+	// Logger is kept for compatibility. If set and SlogLogger is nil, events of
+	// info level and above are written to it as text.
 	Logger *log.Logger
 }
 
@@ -62,7 +92,8 @@ type Config struct {
 type Client struct {
 	config     Config
 	httpClient *http.Client
-	logger     *log.Logger
+	logger     *slog.Logger
+	limiter    *limiter
 }
 
 // New creates a new AEMET client with the provided configuration.
@@ -78,12 +109,13 @@ func New(config Config) (*Client, error) {
 	}
 
 	client := &Client{
-		config: config,
-		logger: config.Logger,
+		config:  config,
+		logger:  newLogger(config),
+		limiter: newLimiter(config.RateLimit),
 	}
 
-	if client.logger == nil {
-		client.logger = log.New(os.Stderr, "", log.LstdFlags)
+	if client.config.Concurrency <= 0 {
+		client.config.Concurrency = defaultConcurrency
 	}
 
 	if config.HTTPClient != nil {
@@ -104,76 +136,288 @@ func NewWithDefaults() (*Client, error) {
 	return New(Config{})
 }
 
-// getRedir performs a two-step request to the AEMET API.
+// getDatos performs the first leg of a two-step request to the AEMET API and
+// returns the response of the datos URL it points to. The caller must close the body.
 // Many AEMET endpoints return a redirect URL that must be followed to get the actual data.
-func (c *Client) getRedir(path string, t any) error {
-	r, err := c.httpClient.Get(fmt.Sprintf("%s/%s?api_key=%s", aemetApi, path, c.config.AemetApiKey))
+func (c *Client) getDatos(ctx context.Context, path string) (*http.Response, error) {
+	if cacheOnly(ctx) {
+		return c.cachedDatos(ctx, path)
+	}
+
+	r, err := c.do(ctx, path, LegMetadata, fmt.Sprintf("%s/%s?api_key=%s", aemetApi, path, c.config.AemetApiKey))
 	if err != nil {
-		return fmt.Errorf("error requesting data: %w", err)
+		return nil, fmt.Errorf("error requesting data: %w", err)
 	}
 	defer r.Body.Close()
 
 	var data map[string]any
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
-		return fmt.Errorf("error decoding data: %w", err)
+		if r.StatusCode >= http.StatusBadRequest {
+			return nil, &APIError{Status: r.StatusCode, Description: r.Status}
+		}
+		c.logger.ErrorContext(ctx, "error decoding metadata", "path", path, "error", err)
+		return nil, fmt.Errorf("error decoding data: %w", err)
+	}
+
+	datos, ok := data["datos"].(string)
+	if !ok {
+		apiErr := &APIError{Description: fmt.Sprint(data["descripcion"])}
+		if estado, ok := data["estado"].(float64); ok {
+			apiErr.Status = int(estado)
+		}
+		return nil, apiErr
+	}
+
+	r, err = c.do(ctx, path, LegData, fmt.Sprintf("%s?api_key=%s", datos, c.config.AemetApiKey))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
 	}
 
-	r, err = c.httpClient.Get(fmt.Sprintf("%s?api_key=%s", data["datos"], c.config.AemetApiKey))
+	return c.storeDatos(ctx, r)
+}
+
+// getRedir performs a two-step request to the AEMET API and decodes the JSON data into t.
+func (c *Client) getRedir(ctx context.Context, path string, t any) error {
+	r, err := c.getDatos(ctx, path)
 	if err != nil {
-		return fmt.Errorf("error requesting data: %w", err)
+		return err
 	}
 	defer r.Body.Close()
 
 	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		c.logger.ErrorContext(ctx, "error decoding data", "path", path, "error", err)
 		return fmt.Errorf("error decoding data: %w", err)
 	}
 
 	return nil
 }
 
-// getRedirWithRetry performs a two-step request with exponential backoff retry logic.
-// This is useful for handling temporary network issues or API rate limits.
-func (c *Client) getRedirWithRetry(path string, t any) error {
+// getRedirText performs a two-step request to the AEMET API for endpoints
+// whose data is plain text rather than JSON. The text is returned as UTF-8.
+func (c *Client) getRedirText(ctx context.Context, path string) (string, error) {
+	r, err := c.getDatos(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading data: %w", err)
+	}
+
+	return decodeText(b), nil
+}
+
+// getRedirImage performs a two-step request to the AEMET API for endpoints
+// whose data is an image, returning its bytes along with the response metadata.
+func (c *Client) getRedirImage(ctx context.Context, path string) (*Image, error) {
+	r, err := c.getDatos(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting data: %s", r.Status)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading data: %w", err)
+	}
+
+	img := &Image{
+		Data:        b,
+		ContentType: r.Header.Get("Content-Type"),
+	}
+	if img.ContentType == "" {
+		img.ContentType = http.DetectContentType(b)
+	}
+	if lm, err := http.ParseTime(r.Header.Get("Last-Modified")); err == nil {
+		img.ValidAt = lm
+	}
+
+	return img, nil
+}
+
+// call runs fn as a single logical request for path, making up to attempts
+// attempts with exponential backoff between them. The data fn read is cached
+// only if it succeeds. When every attempt fails with a transient error and a
+// cache is configured, fn is run once more against the cached data. In offline
+// mode fn only runs against the cache.
+func (c *Client) call(ctx context.Context, path string, attempts int, fn func(ctx context.Context) error) error {
+	ctx, end := c.startCall(ctx, path)
+	err := c.runCall(ctx, path, attempts, fn)
+	end(err)
+	return err
+}
+
+// startCall runs the BeforeCall hooks for a call to path. It returns the
+// context for the call and a function ending it with its final error, which
+// runs the AfterCall hooks. Calls that keep reading data once their requests
+// are done, like streamed ones, end the call themselves.
+func (c *Client) startCall(ctx context.Context, path string) (context.Context, func(err error)) {
+	ctx, status := withCacheStatus(ctx)
+	ctx = c.beforeCall(ctx, CallInfo{Path: path})
+	return ctx, func(err error) {
+		c.afterCall(ctx, CallInfo{Path: path, Cache: *status}, err)
+	}
+}
+
+// runCall runs the attempts of a call started with startCall, falling back
+// on the cache as described in call.
+func (c *Client) runCall(ctx context.Context, path string, attempts int, fn func(ctx context.Context) error) error {
+	ctx, status := withCacheStatus(ctx)
+
+	var err error
+	if c.config.Offline {
+		err = fn(withCacheOnly(ctx))
+	} else {
+		attemptCtx, pending := withPendingEntry(ctx)
+		err = c.attempt(attemptCtx, path, attempts, fn)
+		if err == nil {
+			c.commitEntry(ctx, path, pending)
+		} else if c.config.Cache != nil && ctx.Err() == nil && isTransient(err) {
+			if cacheErr := fn(withCacheOnly(ctx)); cacheErr == nil {
+				c.logger.WarnContext(ctx, "serving cached data",
+					"path", path, "age", status.Age, "error", err)
+				err = nil
+			}
+		}
+	}
+
+	return err
+}
+
+// attempt runs fn up to attempts times with exponential backoff, until it succeeds.
+// Requests rejected for exceeding the AEMET quota back off for longer, so the
+// quota has time to recover.
+func (c *Client) attempt(ctx context.Context, path string, attempts int, fn func(ctx context.Context) error) error {
 	var lastErr error
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; attempt < attempts; attempt++ {
 		if attempt > 0 {
 			backoffMs := baseBackoffMs * int(math.Pow(2, float64(attempt-1)))
-			c.logger.Printf("Retrying request (attempt %d/%d) after %dms backoff", attempt+1, maxRetries+1, backoffMs)
-			time.Sleep(time.Duration(backoffMs) * time.Millisecond)
+			backoff := time.Duration(backoffMs) * time.Millisecond
+			if isRateLimited(lastErr) {
+				backoff = rateLimitBackoff * time.Duration(math.Pow(2, float64(attempt-1)))
+			}
+			c.onRetry(ctx, RetryInfo{Path: path, Attempt: attempt + 1, Backoff: backoff, Err: lastErr})
+			c.logger.InfoContext(ctx, "retrying request",
+				"path", path, "attempt", attempt+1, "attempts", attempts, "backoff", backoff)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
 		}
 
-		err := c.getRedir(path, t)
+		err := fn(withAttempt(ctx, attempt+1))
 		if err == nil {
 			return nil
 		}
 
 		lastErr = err
-		c.logger.Printf("Request failed (attempt %d/%d): %v", attempt+1, maxRetries+1, err)
+		if attempts > 1 {
+			c.logger.WarnContext(ctx, "request failed",
+				"path", path, "attempt", attempt+1, "attempts", attempts, "error", err)
+		}
 	}
 
-	return fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, lastErr)
+	if attempts > 1 {
+		return fmt.Errorf("request failed after %d attempts: %w", attempts, lastErr)
+	}
+	return lastErr
+}
+
+// withRetry calls fn with exponential backoff retry logic.
+// This is useful for handling temporary network issues or API rate limits.
+func (c *Client) withRetry(ctx context.Context, path string, fn func(ctx context.Context) error) error {
+	return c.call(ctx, path, maxRetries+1, fn)
+}
+
+// getRedirWithRetry performs a two-step request with exponential backoff retry logic,
+// reporting whether the data came from the cache.
+func (c *Client) getRedirWithRetry(path string, t any) (CacheStatus, error) {
+	ctx, status := withCacheStatus(context.Background())
+	err := c.getRedirWithRetryContext(ctx, path, t)
+	return *status, err
+}
+
+// getRedirWithRetryContext is getRedirWithRetry with a context.
+func (c *Client) getRedirWithRetryContext(ctx context.Context, path string, t any) error {
+	return c.withRetry(ctx, path, func(ctx context.Context) error {
+		return c.getRedir(ctx, path, t)
+	})
+}
+
+// getRedirTextWithRetry performs a two-step plain text request with exponential backoff
+// retry logic, reporting whether the text came from the cache.
+func (c *Client) getRedirTextWithRetry(path string) (string, CacheStatus, error) {
+	ctx, status := withCacheStatus(context.Background())
+	var text string
+	err := c.withRetry(ctx, path, func(ctx context.Context) error {
+		var err error
+		text, err = c.getRedirText(ctx, path)
+		return err
+	})
+	return text, *status, err
+}
+
+// getRedirImageWithRetry performs a two-step image request with exponential backoff retry logic.
+func (c *Client) getRedirImageWithRetry(path string) (*Image, error) {
+	ctx, status := withCacheStatus(context.Background())
+	var img *Image
+	err := c.withRetry(ctx, path, func(ctx context.Context) error {
+		var err error
+		img, err = c.getRedirImage(ctx, path)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img.Cache = *status
+	return img, nil
 }
 
 // GetStations retrieves a list of all weather stations available in the AEMET network.
 // Returns a slice of WeatherStation structs containing station metadata such as
 // location, altitude, and identification codes.
 func (c *Client) GetStations() ([]WeatherStation, error) {
+	stations, _, err := c.GetStationsWithStatus()
+	return stations, err
+}
+
+// GetStationsWithStatus is like GetStations, also telling whether the stations
+// were served from the client cache.
+func (c *Client) GetStationsWithStatus() ([]WeatherStation, CacheStatus, error) {
+	ctx, status := withCacheStatus(context.Background())
 	var stations []WeatherStation
-	err := c.getRedir("api/valores/climatologicos/inventarioestaciones/todasestaciones", &stations)
+	path := "api/valores/climatologicos/inventarioestaciones/todasestaciones"
+	err := c.withRetry(ctx, path, func(ctx context.Context) error {
+		return c.getRedir(ctx, path, &stations)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error requesting data: %w", err)
+		return nil, CacheStatus{}, fmt.Errorf("error requesting data: %w", err)
 	}
 
-	return stations, nil
+	return stations, *status, nil
 }
 
 // GetForecastFor retrieves the daily weather forecast for a municipality using its official ID.
 // The municipality ID should be the official INE (National Statistics Institute) code.
 // Returns detailed forecast information including temperature, precipitation, wind, and other meteorological data.
 func (c *Client) GetForecastFor(muni string) (*Municipality, error) {
+	return c.getForecastFor(context.Background(), muni)
+}
+
+func (c *Client) getForecastFor(ctx context.Context, muni string) (*Municipality, error) {
+	ctx, status := withCacheStatus(ctx)
 	var m []*Municipality
-	err := c.getRedirWithRetry(fmt.Sprintf("api/prediccion/especifica/municipio/diaria/%s", muni), &m)
+	err := c.getRedirWithRetryContext(ctx, fmt.Sprintf("api/prediccion/especifica/municipio/diaria/%s", muni), &m)
 	if err != nil {
 		return nil, fmt.Errorf("error requesting data: %w", err)
 	}
@@ -182,6 +426,7 @@ func (c *Client) GetForecastFor(muni string) (*Municipality, error) {
 		return nil, fmt.Errorf("no data found for municipality %s", muni)
 	}
 
+	m[0].Cache = *status
 	return m[0], nil
 }
 
@@ -0,0 +1,115 @@
+package aemet
+
+import (
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubiojr/aemet-go/replay"
+)
+
+var record = flag.Bool("record", false, "record the replay fixtures from opendata.aemet.es, using AEMET_API_KEY")
+
+const replayDir = "testdata/replay"
+
+// replayClient returns a client serving the exchanges recorded in
+// testdata/replay. With -record, it records them from the live API instead:
+//
+//	AEMET_API_KEY=... go test -run Replay -record
+func replayClient(t *testing.T) *Client {
+	t.Helper()
+
+	if *record {
+		key := os.Getenv("AEMET_API_KEY")
+		if key == "" {
+			t.Skip("recording requires AEMET_API_KEY")
+		}
+		client, err := New(Config{
+			AemetApiKey: key,
+			HTTPClient:  &http.Client{Transport: &replay.Recorder{Dir: replayDir}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	r, err := replay.NewReplayer(replayDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(Config{
+		AemetApiKey: "replay",
+		HTTPClient:  &http.Client{Transport: r},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// skipUnrecorded skips the test when err comes from an exchange missing in
+// testdata/replay
+func skipUnrecorded(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, replay.ErrNoFixture) {
+		t.Skip("exchange not recorded; run go test -run Replay -record with AEMET_API_KEY")
+	}
+}
+
+func TestGetForecastForReplay(t *testing.T) {
+	m, err := replayClient(t).GetForecastFor("28079")
+	skipUnrecorded(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Nombre != "Madrid" || m.Provincia != "Madrid" {
+		t.Errorf("municipality = %q (%q), want Madrid (Madrid)", m.Nombre, m.Provincia)
+	}
+	if len(m.Prediccion.Dia) == 0 {
+		t.Fatal("no forecast days")
+	}
+	for i, d := range m.Prediccion.Dia[1:] {
+		prev := m.Prediccion.Dia[i].Fecha
+		if want := prev.AddDate(0, 0, 1); !d.Fecha.Equal(want) {
+			t.Errorf("day %d = %s, want %s", i+1, d.Fecha.Format(time.DateOnly), want.Format(time.DateOnly))
+		}
+	}
+	if m.Origen.Productor == "" || m.Elaborado.IsZero() {
+		t.Errorf("origen = %+v, elaborado = %s, want both set", m.Origen, m.Elaborado)
+	}
+}
+
+func TestGetForecastForReplayMissing(t *testing.T) {
+	if *record {
+		t.Skip("nothing to record")
+	}
+	_, err := replayClient(t).GetForecastFor("08019")
+	if !errors.Is(err, replay.ErrNoFixture) {
+		t.Errorf("GetForecastFor() error = %v, want ErrNoFixture", err)
+	}
+}
+
+func TestGetProvinceForecastReplay(t *testing.T) {
+	f, err := replayClient(t).GetProvinceForecast(ProvinceMadrid, TextToday)
+	skipUnrecorded(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AEMET serves plain text products in ISO-8859-15
+	if !strings.Contains(f.Text, "MADRID") || strings.ContainsRune(f.Text, '�') {
+		t.Errorf("text not decoded: %q", f.Text)
+	}
+	if f.Scope != ScopeProvince || f.Period != TextToday || f.Area != "28" {
+		t.Errorf("forecast = %s/%s/%s, want provincia/hoy/28", f.Scope, f.Period, f.Area)
+	}
+	if len(f.Sections) == 0 {
+		t.Error("no sections")
+	}
+}
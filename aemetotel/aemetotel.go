@@ -0,0 +1,235 @@
+// Package aemetotel provides OpenTelemetry tracing and metrics for the AEMET client.
+//
+// Instrumentation is added as a client hook:
+//
+//	hook, err := aemetotel.Hook()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client, err := aemet.New(aemet.Config{Hooks: []aemet.Hook{hook}})
+//
+// Every logical call, such as GetForecastFor, gets a span with child spans for
+// the metadata and datos requests and for each retry backoff. The global tracer
+// and meter providers are used unless others are given; they are no-ops until
+// an SDK is installed.
+package aemetotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rubiojr/aemet-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const scopeName = "github.com/rubiojr/aemet-go/aemetotel"
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The global one is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the meter provider. The global one is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+type instrumentation struct {
+	tracer          trace.Tracer
+	callDuration    metric.Float64Histogram
+	requestDuration metric.Float64Histogram
+	retries         metric.Int64Counter
+	errors          metric.Int64Counter
+	cacheHits       metric.Int64Counter
+}
+
+type callStartKey struct{}
+
+// Hook returns a client hook recording traces and metrics.
+//
+// Metrics recorded:
+//   - aemet.client.call.duration: duration of logical calls, retries included
+//   - aemet.client.request.duration: duration of each metadata and datos request
+//   - aemet.client.retries: number of retried attempts
+//   - aemet.client.errors: failed calls, by AEMET "estado" code when AEMET sent one
+//   - aemet.client.cache.hits: calls served from the client cache
+func Hook(opts ...Option) (aemet.Hook, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(scopeName)
+	inst := &instrumentation{tracer: cfg.tracerProvider.Tracer(scopeName)}
+
+	var err error
+	if inst.callDuration, err = meter.Float64Histogram("aemet.client.call.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of AEMET calls, retries included")); err != nil {
+		return aemet.Hook{}, err
+	}
+	if inst.requestDuration, err = meter.Float64Histogram("aemet.client.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of AEMET metadata and datos requests")); err != nil {
+		return aemet.Hook{}, err
+	}
+	if inst.retries, err = meter.Int64Counter("aemet.client.retries",
+		metric.WithDescription("Number of retried AEMET call attempts")); err != nil {
+		return aemet.Hook{}, err
+	}
+	if inst.errors, err = meter.Int64Counter("aemet.client.errors",
+		metric.WithDescription("Number of failed AEMET calls")); err != nil {
+		return aemet.Hook{}, err
+	}
+	if inst.cacheHits, err = meter.Int64Counter("aemet.client.cache.hits",
+		metric.WithDescription("Number of AEMET calls served from the client cache")); err != nil {
+		return aemet.Hook{}, err
+	}
+
+	return aemet.Hook{
+		BeforeCall:    inst.beforeCall,
+		AfterCall:     inst.afterCall,
+		BeforeRequest: inst.beforeRequest,
+		AfterResponse: inst.afterResponse,
+		OnRetry:       inst.onRetry,
+	}, nil
+}
+
+// Operation returns the API path with its identifiers removed, e.g.
+// "api/prediccion/especifica/municipio/diaria" for a municipality forecast.
+// It keeps span names and metric attributes low-cardinality.
+func Operation(path string) string {
+	segments := strings.Split(path, "/")
+	kept := segments[:0]
+	for _, s := range segments {
+		if s == "" || strings.IndexFunc(s, unicode.IsDigit) >= 0 {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return strings.Join(kept, "/")
+}
+
+func (i *instrumentation) beforeCall(ctx context.Context, call aemet.CallInfo) context.Context {
+	op := Operation(call.Path)
+	ctx, _ = i.tracer.Start(ctx, "aemet "+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("aemet.operation", op),
+			attribute.String("aemet.path", call.Path),
+		))
+	return context.WithValue(ctx, callStartKey{}, time.Now())
+}
+
+func (i *instrumentation) afterCall(ctx context.Context, call aemet.CallInfo, err error) {
+	attrs := []attribute.KeyValue{attribute.String("aemet.operation", Operation(call.Path))}
+	span := trace.SpanFromContext(ctx)
+
+	span.SetAttributes(attribute.Bool("aemet.cache.stale", call.Cache.Stale))
+	if call.Cache.Stale {
+		i.cacheHits.Add(ctx, 1, metric.WithAttributes(attrs...))
+		span.SetAttributes(attribute.Float64("aemet.cache.age_s", call.Cache.Age.Seconds()))
+	}
+
+	if err != nil {
+		attrs = append(attrs, errorAttributes(err)...)
+		i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	if start, ok := ctx.Value(callStartKey{}).(time.Time); ok {
+		i.callDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+	span.End()
+}
+
+func (i *instrumentation) beforeRequest(req *http.Request, info aemet.RequestInfo) *http.Request {
+	ctx, _ := i.tracer.Start(req.Context(), "aemet "+info.Leg.String(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("aemet.leg", info.Leg.String()),
+			attribute.Int("aemet.attempt", info.Attempt),
+			attribute.String("http.request.method", req.Method),
+		))
+	return req.WithContext(ctx)
+}
+
+func (i *instrumentation) afterResponse(req *http.Request, resp *http.Response, info aemet.RequestInfo, err error) {
+	ctx := req.Context()
+	span := trace.SpanFromContext(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.String("aemet.operation", Operation(info.Path)),
+		attribute.String("aemet.leg", info.Leg.String()),
+	}
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		attrs = append(attrs, attribute.String("error.type", "transport"))
+	default:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+
+	i.requestDuration.Record(ctx, info.Elapsed.Seconds(), metric.WithAttributes(attrs...))
+	span.End()
+}
+
+func (i *instrumentation) onRetry(ctx context.Context, info aemet.RetryInfo) {
+	op := Operation(info.Path)
+	start := time.Now()
+	_, span := i.tracer.Start(ctx, "aemet retry",
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.Int("aemet.attempt", info.Attempt),
+			attribute.Int64("aemet.backoff_ms", info.Backoff.Milliseconds()),
+		))
+	if info.Err != nil {
+		span.RecordError(info.Err)
+	}
+	// The span covers the backoff wait before the next attempt
+	span.End(trace.WithTimestamp(start.Add(info.Backoff)))
+
+	i.retries.Add(ctx, 1, metric.WithAttributes(attribute.String("aemet.operation", op)))
+}
+
+// errorAttributes describes err for metrics: the AEMET estado code when AEMET
+// rejected the request, or the kind of failure otherwise.
+func errorAttributes(err error) []attribute.KeyValue {
+	var apiErr *aemet.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return []attribute.KeyValue{
+			attribute.String("error.type", "aemet"),
+			attribute.String("aemet.status", strconv.Itoa(apiErr.Status)),
+		}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return []attribute.KeyValue{attribute.String("error.type", "canceled")}
+	default:
+		return []attribute.KeyValue{attribute.String("error.type", "other")}
+	}
+}
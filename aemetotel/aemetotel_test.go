@@ -0,0 +1,288 @@
+package aemetotel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rubiojr/aemet-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const stationsPath = "api/valores/climatologicos/inventarioestaciones/todasestaciones"
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// stationsServer answers the station inventory with the given metadata
+// responses in turn, then with a successful one, and datos for the data leg
+func stationsServer(datos string, metadata ...*http.Response) roundTripFunc {
+	var mu sync.Mutex
+	return func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/sh/") {
+			return response(http.StatusOK, datos), nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(metadata) == 0 {
+			return response(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/stations"}`), nil
+		}
+		resp := metadata[0]
+		metadata = metadata[1:]
+		return resp, nil
+	}
+}
+
+type telemetry struct {
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+}
+
+func newClient(t *testing.T, config aemet.Config) (*aemet.Client, *telemetry) {
+	t.Helper()
+
+	tel := &telemetry{spans: tracetest.NewSpanRecorder(), reader: sdkmetric.NewManualReader()}
+	hook, err := Hook(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tel.spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(tel.reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.AemetApiKey = "test"
+	config.Hooks = []aemet.Hook{hook}
+	client, err := aemet.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, tel
+}
+
+// metrics returns the collected metrics by name
+func (tel *telemetry) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := tel.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func counterSum(t *testing.T, data metricdata.Aggregation) (int64, attribute.Set) {
+	t.Helper()
+
+	sum, ok := data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 {
+		t.Fatalf("counter = %+v, want one data point", data)
+	}
+	return sum.DataPoints[0].Value, sum.DataPoints[0].Attributes
+}
+
+func histogramCount(t *testing.T, data metricdata.Aggregation) uint64 {
+	t.Helper()
+
+	h, ok := data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("histogram = %+v", data)
+	}
+	var count uint64
+	for _, dp := range h.DataPoints {
+		count += dp.Count
+	}
+	return count
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestCallSpans(t *testing.T) {
+	client, tel := newClient(t, aemet.Config{
+		HTTPClient: &http.Client{Transport: stationsServer(`[{"indicativo": "3195"}]`,
+			response(http.StatusInternalServerError, `{"descripcion": "Error interno", "estado": 500}`),
+		)},
+	})
+
+	if _, err := client.GetStations(); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tel.spans.Ended()
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	want := []string{"aemet metadata", "aemet retry", "aemet metadata", "aemet datos", "aemet " + stationsPath}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("spans = %q, want %q", names, want)
+	}
+
+	call := spans[len(spans)-1]
+	if call.Status().Code == codes.Error {
+		t.Errorf("call span status = %v", call.Status())
+	}
+	if got := attr(call.Attributes(), "aemet.operation").AsString(); got != stationsPath {
+		t.Errorf("call operation = %q", got)
+	}
+	for _, s := range spans[:len(spans)-1] {
+		if s.Parent().SpanID() != call.SpanContext().SpanID() {
+			t.Errorf("%s span is not a child of the call span", s.Name())
+		}
+	}
+
+	failed, retry, data := spans[0], spans[1], spans[3]
+	if failed.Status().Code != codes.Error || attr(failed.Attributes(), "http.response.status_code").AsInt64() != 500 {
+		t.Errorf("failed metadata span = %v, %v", failed.Status(), failed.Attributes())
+	}
+	if attr(retry.Attributes(), "aemet.attempt").AsInt64() != 2 || len(retry.Events()) != 1 {
+		t.Errorf("retry span attributes = %v, events = %v", retry.Attributes(), retry.Events())
+	}
+	if d := retry.EndTime().Sub(retry.StartTime()); d != 100*time.Millisecond {
+		t.Errorf("retry span covers %s, want the 100ms backoff", d)
+	}
+	if attr(data.Attributes(), "aemet.leg").AsString() != "datos" || attr(data.Attributes(), "aemet.attempt").AsInt64() != 2 {
+		t.Errorf("datos span attributes = %v", data.Attributes())
+	}
+
+	metrics := tel.metrics(t)
+	if n := histogramCount(t, metrics["aemet.client.call.duration"]); n != 1 {
+		t.Errorf("%d call durations, want 1", n)
+	}
+	if n := histogramCount(t, metrics["aemet.client.request.duration"]); n != 3 {
+		t.Errorf("%d request durations, want 3", n)
+	}
+	if n, _ := counterSum(t, metrics["aemet.client.retries"]); n != 1 {
+		t.Errorf("%d retries, want 1", n)
+	}
+	if _, ok := metrics["aemet.client.errors"]; ok {
+		t.Error("errors recorded for a successful call")
+	}
+}
+
+func TestCallErrorMetrics(t *testing.T) {
+	// Every attempt of the call is rejected
+	var unauthorized []*http.Response
+	for range 4 {
+		unauthorized = append(unauthorized,
+			response(http.StatusUnauthorized, `{"descripcion": "API key invalido", "estado": 401}`))
+	}
+	client, tel := newClient(t, aemet.Config{
+		HTTPClient: &http.Client{Transport: stationsServer("", unauthorized...)},
+	})
+
+	if _, err := client.GetStations(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	spans := tel.spans.Ended()
+	call := spans[len(spans)-1]
+	if call.Status().Code != codes.Error {
+		t.Errorf("call span status = %v, want error", call.Status())
+	}
+
+	n, attrs := counterSum(t, tel.metrics(t)["aemet.client.errors"])
+	if n != 1 {
+		t.Errorf("%d errors, want 1", n)
+	}
+	if v, _ := attrs.Value("aemet.status"); v.AsString() != "401" {
+		t.Errorf("error attributes = %v, want aemet.status 401", attrs.ToSlice())
+	}
+	if v, _ := attrs.Value("error.type"); v.AsString() != "aemet" {
+		t.Errorf("error attributes = %v, want error.type aemet", attrs.ToSlice())
+	}
+}
+
+type memCache map[string]aemet.CacheEntry
+
+func (c memCache) Get(path string) (aemet.CacheEntry, bool) {
+	e, ok := c[path]
+	return e, ok
+}
+
+func (c memCache) Set(path string, entry aemet.CacheEntry) error {
+	c[path] = entry
+	return nil
+}
+
+func TestCacheHitMetrics(t *testing.T) {
+	cache := memCache{stationsPath: {
+		Data:   []byte(`[{"indicativo": "3195"}]`),
+		Stored: time.Now().Add(-time.Hour),
+	}}
+	client, tel := newClient(t, aemet.Config{Cache: cache, Offline: true})
+
+	if _, err := client.GetStations(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, _ := counterSum(t, tel.metrics(t)["aemet.client.cache.hits"]); n != 1 {
+		t.Errorf("%d cache hits, want 1", n)
+	}
+	call := tel.spans.Ended()[0]
+	if !attr(call.Attributes(), "aemet.cache.stale").AsBool() || attr(call.Attributes(), "aemet.cache.age_s").AsFloat64() < 3600 {
+		t.Errorf("call span attributes = %v", call.Attributes())
+	}
+}
+
+func TestStreamDecodeError(t *testing.T) {
+	client, tel := newClient(t, aemet.Config{
+		HTTPClient: &http.Client{Transport: stationsServer(`[{"indicativo": "3195"}, {"indicativo": 3`)},
+	})
+
+	var decoded int
+	var streamErr error
+	for _, err := range client.StreamStations() {
+		if err != nil {
+			streamErr = err
+			break
+		}
+		decoded++
+	}
+	if decoded != 1 || streamErr == nil {
+		t.Fatalf("decoded %d stations, error %v; want 1 and an error", decoded, streamErr)
+	}
+
+	// The call span ends after decoding, with the decoding error
+	spans := tel.spans.Ended()
+	call := spans[len(spans)-1]
+	if call.Name() != "aemet "+stationsPath || call.Status().Code != codes.Error {
+		t.Errorf("call span %q status = %v, want error", call.Name(), call.Status())
+	}
+	if n, attrs := counterSum(t, tel.metrics(t)["aemet.client.errors"]); n != 1 {
+		t.Errorf("%d errors (%v), want 1", n, attrs.ToSlice())
+	}
+}
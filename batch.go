@@ -0,0 +1,53 @@
+package aemet
+
+import (
+	"context"
+	"sync"
+)
+
+// ForecastResult is the outcome of fetching the forecast of one municipality in a batch
+type ForecastResult struct {
+	ID       string
+	Forecast *Municipality
+	Err      error
+}
+
+// GetForecasts retrieves the daily forecasts of several municipalities concurrently,
+// running at most Config.Concurrency requests at once. Results are returned in the
+// order of ids; a failed municipality sets its Err without aborting the rest.
+//
+// Requests share the client rate limiter, so set Config.RateLimit to keep large
+// batches under the AEMET quota. Requests AEMET rejects for exceeding it are
+// retried after a longer backoff. Once ctx is done in-flight requests are
+// cancelled, and pending municipalities get ctx.Err().
+func (c *Client) GetForecasts(ctx context.Context, ids []string) []ForecastResult {
+	results := make([]ForecastResult, len(ids))
+	sem := make(chan struct{}, c.config.Concurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		results[i].ID = id
+
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Forecast, results[i].Err = c.getForecastFor(ctx, id)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,159 @@
+package aemet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// EstadoPlaya represents a beach condition forecast for the morning (1) and afternoon (2).
+// F1 and F2 are AEMET condition codes, the descriptions are in Spanish.
+type EstadoPlaya struct {
+	Value        string `json:"value"`
+	F1           Int    `json:"f1"`
+	Descripcion1 string `json:"descripcion1"`
+	F2           Int    `json:"f2"`
+	Descripcion2 string `json:"descripcion2"`
+}
+
+// ValorPlaya represents a single daily beach value, optionally with a description
+type ValorPlaya struct {
+	Value        string `json:"value"`
+	Valor1       Int    `json:"valor1"`
+	Descripcion1 string `json:"descripcion1,omitempty"`
+}
+
+// DiaPlaya represents a day's beach forecast
+type DiaPlaya struct {
+	EstadoCielo EstadoPlaya `json:"estadoCielo"`
+	Viento      EstadoPlaya `json:"viento"`
+	Oleaje      EstadoPlaya `json:"oleaje"`
+	// TMaxima is the maximum air temperature in °C.
+	TMaxima ValorPlaya `json:"tMaxima"`
+	// STermica is the thermal sensation code with its description, e.g. "calor agradable".
+	STermica ValorPlaya `json:"sTermica"`
+	// TAgua is the water temperature in °C.
+	TAgua ValorPlaya `json:"tAgua"`
+	UvMax ValorPlaya `json:"uvMax"`
+	Fecha time.Time  `json:"fecha"`
+}
+
+// PrediccionPlaya represents the beach prediction structure
+type PrediccionPlaya struct {
+	Dia []DiaPlaya `json:"dia"`
+}
+
+// Beach represents a beach forecast
+type Beach struct {
+	Origen     Origen          `json:"origen"`
+	Elaborado  time.Time       `json:"elaborado"`
+	Nombre     string          `json:"nombre"`
+	Localidad  int             `json:"localidad"`
+	Prediccion PrediccionPlaya `json:"prediccion"`
+	ID         int             `json:"id"`
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// AEMET sends beach forecast dates as numbers like 20250520.
+func (d *DiaPlaya) UnmarshalJSON(b []byte) error {
+	type diaPlaya DiaPlaya
+	aux := struct {
+		*diaPlaya
+		Fecha Int `json:"fecha"`
+	}{diaPlaya: (*diaPlaya)(d)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	d.Fecha = time.Time{}
+	if aux.Fecha.Valid {
+		fecha, err := time.ParseInLocation("20060102", strconv.Itoa(aux.Fecha.Value), madridLocation())
+		if err != nil {
+			return fmt.Errorf("error decoding fecha: %w", err)
+		}
+		d.Fecha = fecha
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d DiaPlaya) MarshalJSON() ([]byte, error) {
+	type diaPlaya DiaPlaya
+	aux := struct {
+		diaPlaya
+		Fecha Int `json:"fecha"`
+	}{diaPlaya: diaPlaya(d)}
+
+	if !d.Fecha.IsZero() {
+		fecha, _ := strconv.Atoi(d.Fecha.Format("20060102"))
+		aux.Fecha = NewInt(fecha)
+	}
+
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Elaborado and every DiaPlaya.Fecha are expressed in the time zone of the
+// beach's municipality, see MunicipalityLocation.
+func (p *Beach) UnmarshalJSON(b []byte) error {
+	type beach Beach
+	aux := struct {
+		*beach
+		Elaborado string `json:"elaborado"`
+	}{beach: (*beach)(p)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	loc := MunicipalityLocation(strconv.Itoa(p.Localidad))
+	elaborado, err := parseAemetTime(aux.Elaborado, loc)
+	if err != nil {
+		return fmt.Errorf("error decoding elaborado: %w", err)
+	}
+	p.Elaborado = elaborado
+
+	for i := range p.Prediccion.Dia {
+		p.Prediccion.Dia[i].Fecha = reanchor(p.Prediccion.Dia[i].Fecha, loc)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Beach) MarshalJSON() ([]byte, error) {
+	type beach Beach
+	return json.Marshal(struct {
+		beach
+		Elaborado string `json:"elaborado"`
+	}{beach: beach(p), Elaborado: formatAemetTime(p.Elaborado)})
+}
+
+// MunicipalityInfo returns the municipality the beach belongs to
+func (p *Beach) MunicipalityInfo() (*MunicipalityInfo, error) {
+	return GetMunicipalityByID(fmt.Sprintf("%05d", p.Localidad))
+}
+
+// GetBeachForecast retrieves the forecast for a beach using its AEMET beach ID,
+// as listed in the beach catalogue.
+// Returns sky, wind and wave conditions for the morning and afternoon, along with
+// air and water temperature, thermal sensation and UV index for the next days.
+func (c *Client) GetBeachForecast(id string) (*Beach, error) {
+	var b []*Beach
+	status, err := c.getRedirWithRetry(fmt.Sprintf("api/prediccion/especifica/playa/%s", id), &b)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("no data found for beach %s", id)
+	}
+
+	b[0].Cache = status
+	return b[0], nil
+}
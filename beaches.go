@@ -0,0 +1,104 @@
+package aemet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./internal/beachgen -o beaches_data.go Playas_codigos.csv
+
+// BeachInfo represents a beach in the AEMET beach forecast catalogue
+type BeachInfo struct {
+	ID             string `json:"id"`
+	Name           string `json:"nombre"`
+	ProvinceID     string `json:"id_provincia"`
+	Province       string `json:"provincia"`
+	MunicipalityID string `json:"id_municipio"`
+	Municipality   string `json:"municipio"`
+	// Latitude and Longitude are given as in Playas_codigos.csv.
+	Latitude  string `json:"latitud"`
+	Longitude string `json:"longitud"`
+}
+
+// ErrNoBeachCatalogue is returned by the beach lookups when the embedded
+// catalogue has not been generated from Playas_codigos.csv.
+var ErrNoBeachCatalogue = errors.New("beach catalogue not generated")
+
+// loadBeaches parses the embedded beach catalogue once
+var loadBeaches = sync.OnceValues(func() ([]*BeachInfo, error) {
+	var beaches []*BeachInfo
+	if err := json.Unmarshal([]byte(beachesJSON), &beaches); err != nil {
+		return nil, fmt.Errorf("error parsing beaches data: %w", err)
+	}
+	if len(beaches) == 0 {
+		return nil, ErrNoBeachCatalogue
+	}
+	return beaches, nil
+})
+
+// FindBeachesByPartialName searches for beaches by partial name match
+func FindBeachesByPartialName(partialName string) ([]*BeachInfo, error) {
+	beaches, err := loadBeaches()
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedPartial := strings.ToLower(strings.TrimSpace(partialName))
+	var results []*BeachInfo
+
+	for _, beach := range beaches {
+		if strings.Contains(strings.ToLower(beach.Name), normalizedPartial) {
+			results = append(results, beach)
+		}
+	}
+
+	return results, nil
+}
+
+// FindBeachesByMunicipality returns the beaches in the municipality with the given ID
+func FindBeachesByMunicipality(municipalityID string) ([]*BeachInfo, error) {
+	beaches, err := loadBeaches()
+	if err != nil {
+		return nil, err
+	}
+
+	municipalityID = strings.TrimPrefix(municipalityID, "id")
+	var results []*BeachInfo
+
+	for _, beach := range beaches {
+		if beach.MunicipalityID == municipalityID {
+			results = append(results, beach)
+		}
+	}
+
+	return results, nil
+}
+
+// GetAllBeaches returns all beaches
+func GetAllBeaches() ([]*BeachInfo, error) {
+	return loadBeaches()
+}
+
+// GetBeachByID returns a beach by its ID
+func GetBeachByID(id string) (*BeachInfo, error) {
+	beaches, err := loadBeaches()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, beach := range beaches {
+		if beach.ID == id {
+			return beach, nil
+		}
+	}
+
+	return nil, fmt.Errorf("beach ID not found: %s", id)
+}
+
+// MunicipalityInfo returns the municipality the beach belongs to
+func (b *BeachInfo) MunicipalityInfo() (*MunicipalityInfo, error) {
+	return GetMunicipalityByID(b.MunicipalityID)
+}
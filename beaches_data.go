@@ -0,0 +1,7 @@
+package aemet
+
+// beachesJSON holds the AEMET beach catalogue, generated by go generate from
+// Playas_codigos.csv (https://www.aemet.es/es/eltiempo/prediccion/playas).
+// It is empty until the file is downloaded and the catalogue generated; the
+// beach lookups return ErrNoBeachCatalogue meanwhile.
+const beachesJSON = `[]`
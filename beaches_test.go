@@ -0,0 +1,89 @@
+package aemet
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testBeaches replaces the embedded beach catalogue for the test. The entries
+// are made up, not taken from Playas_codigos.csv.
+func testBeaches(t *testing.T) {
+	t.Helper()
+
+	var beaches []*BeachInfo
+	err := json.Unmarshal([]byte(`[
+		{"id": "2006901", "nombre": "La Concha", "id_provincia": "20", "provincia": "Gipuzkoa", "id_municipio": "20069", "municipio": "Donostia/San Sebastián"},
+		{"id": "2006902", "nombre": "Ondarreta", "id_provincia": "20", "provincia": "Gipuzkoa", "id_municipio": "20069", "municipio": "Donostia/San Sebastián"},
+		{"id": "3907501", "nombre": "El Sardinero", "id_provincia": "39", "provincia": "Cantabria", "id_municipio": "39075", "municipio": "Santander"},
+		{"id": "9999901", "nombre": "Playa Perdida", "id_provincia": "99", "provincia": "Ninguna", "id_municipio": "99999", "municipio": "Ninguno"}
+	]`), &beaches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load := loadBeaches
+	t.Cleanup(func() { loadBeaches = load })
+	loadBeaches = func() ([]*BeachInfo, error) { return beaches, nil }
+}
+
+func TestFindBeaches(t *testing.T) {
+	testBeaches(t)
+
+	found, err := FindBeachesByPartialName("  concha ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != "2006901" {
+		t.Errorf("FindBeachesByPartialName() = %v, want La Concha", found)
+	}
+
+	for _, id := range []string{"20069", "id20069"} {
+		found, err = FindBeachesByMunicipality(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 2 {
+			t.Errorf("FindBeachesByMunicipality(%q) found %d beaches, want 2", id, len(found))
+		}
+	}
+
+	beach, err := GetBeachByID("3907501")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beach.Name != "El Sardinero" {
+		t.Errorf("GetBeachByID() = %q, want El Sardinero", beach.Name)
+	}
+	if _, err := GetBeachByID("0000000"); err == nil {
+		t.Error("expected an error for an unknown beach")
+	}
+}
+
+func TestBeachMunicipalityInfo(t *testing.T) {
+	testBeaches(t)
+
+	for id, want := range map[string]string{
+		"2006901": "Donostia/San Sebastián",
+		"3907501": "Santander",
+	} {
+		beach, err := GetBeachByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := beach.MunicipalityInfo()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Name != want {
+			t.Errorf("beach %s municipality = %q, want %q", id, m.Name, want)
+		}
+	}
+
+	beach, err := GetBeachByID("9999901")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := beach.MunicipalityInfo(); err == nil {
+		t.Error("expected an error for an unknown municipality")
+	}
+}
@@ -0,0 +1,224 @@
+package aemet
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotCached is returned in offline mode when a request has no cached data.
+var ErrNotCached = errors.New("no cached data")
+
+// CacheEntry is a cached AEMET payload
+type CacheEntry struct {
+	Data []byte `json:"data"`
+	// ContentType and LastModified are the headers of the datos response.
+	ContentType  string    `json:"content_type,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Stored       time.Time `json:"stored"`
+}
+
+// Cache stores the last payload retrieved for each API path.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(path string) (CacheEntry, bool)
+	Set(path string, entry CacheEntry) error
+}
+
+// CacheStatus tells whether a result was served from the cache instead of the API
+type CacheStatus struct {
+	// Stale is true when the data comes from the cache, either because the
+	// client is offline or because the API was unavailable.
+	Stale bool
+	// Age is how long ago the cached data was retrieved.
+	Age time.Duration
+}
+
+// FileCache is a Cache storing one file per API path in a directory
+type FileCache struct {
+	Dir string
+}
+
+// NewFileCache returns a FileCache in dir, creating it if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+	return &FileCache{Dir: dir}, nil
+}
+
+func (c *FileCache) file(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(path string) (CacheEntry, bool) {
+	b, err := os.ReadFile(c.file(path))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set implements Cache.
+func (c *FileCache) Set(path string, entry CacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding cache entry: %w", err)
+	}
+
+	// Write to a temporary file first, so readers never see a partial entry
+	tmp, err := os.CreateTemp(c.Dir, "entry-*")
+	if err != nil {
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.file(path)); err != nil {
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	return nil
+}
+
+type cacheOnlyKey struct{}
+type cacheStatusKey struct{}
+
+// withCacheOnly marks ctx so requests are served from the cache only
+func withCacheOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheOnlyKey{}, true)
+}
+
+func cacheOnly(ctx context.Context) bool {
+	only, _ := ctx.Value(cacheOnlyKey{}).(bool)
+	return only
+}
+
+// withCacheStatus returns a context carrying a CacheStatus that calls made
+// with it fill in, reusing the one already in ctx if any.
+func withCacheStatus(ctx context.Context) (context.Context, *CacheStatus) {
+	if status, ok := ctx.Value(cacheStatusKey{}).(*CacheStatus); ok {
+		return ctx, status
+	}
+	status := &CacheStatus{}
+	return context.WithValue(ctx, cacheStatusKey{}, status), status
+}
+
+// cachedDatos serves the cached payload for path as if it were the datos response
+func (c *Client) cachedDatos(ctx context.Context, path string) (*http.Response, error) {
+	if c.config.Cache == nil {
+		return nil, fmt.Errorf("offline mode requires a cache: %w", ErrNotCached)
+	}
+
+	entry, ok := c.config.Cache.Get(path)
+	if !ok {
+		return nil, fmt.Errorf("%w for %s", ErrNotCached, path)
+	}
+
+	if status, ok := ctx.Value(cacheStatusKey{}).(*CacheStatus); ok {
+		status.Stale = true
+		status.Age = time.Since(entry.Stored)
+	}
+
+	header := http.Header{}
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	if entry.LastModified != "" {
+		header.Set("Last-Modified", entry.LastModified)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Data)),
+		ContentLength: int64(len(entry.Data)),
+	}, nil
+}
+
+// pendingEntry holds the payload read by the current attempt of a call. It is
+// only written to the cache once the call succeeds, so payloads that fail to
+// decode never replace good cached data.
+type pendingEntry struct {
+	entry *CacheEntry
+}
+
+type pendingEntryKey struct{}
+type noStoreKey struct{}
+
+func withPendingEntry(ctx context.Context) (context.Context, *pendingEntry) {
+	pending := &pendingEntry{}
+	return context.WithValue(ctx, pendingEntryKey{}, pending), pending
+}
+
+// withoutStore marks ctx so datos responses are not cached. Streamed calls use
+// it to keep memory constant, since caching requires reading the whole payload.
+func withoutStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noStoreKey{}, true)
+}
+
+func noStore(ctx context.Context) bool {
+	skip, _ := ctx.Value(noStoreKey{}).(bool)
+	return skip
+}
+
+// storeDatos keeps the body of a successful datos response to be cached when
+// the call succeeds, and returns an equivalent response for the caller to read
+func (c *Client) storeDatos(ctx context.Context, r *http.Response) (*http.Response, error) {
+	pending, ok := ctx.Value(pendingEntryKey{}).(*pendingEntry)
+	if c.config.Cache == nil || !ok || noStore(ctx) {
+		return r, nil
+	}
+	pending.entry = nil
+	if r.StatusCode != http.StatusOK {
+		return r, nil
+	}
+
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading data: %w", err)
+	}
+
+	pending.entry = &CacheEntry{
+		Data:         b,
+		ContentType:  r.Header.Get("Content-Type"),
+		LastModified: r.Header.Get("Last-Modified"),
+		Stored:       time.Now(),
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return r, nil
+}
+
+// commitEntry writes the payload of a successful call to the cache
+func (c *Client) commitEntry(ctx context.Context, path string, pending *pendingEntry) {
+	if pending.entry == nil {
+		return
+	}
+	if err := c.config.Cache.Set(path, *pending.entry); err != nil {
+		c.logger.WarnContext(ctx, "error caching data", "path", path, "error", err)
+	}
+}
@@ -0,0 +1,293 @@
+package aemet
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memCache is a Cache keeping entries in memory
+type memCache struct {
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+func (c *memCache) Get(path string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[path]
+	return entry, ok
+}
+
+func (c *memCache) Set(path string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]CacheEntry)
+	}
+	c.entries[path] = entry
+	return nil
+}
+
+func TestCacheSkipsUndecodableData(t *testing.T) {
+	const path = "api/prediccion/especifica/municipio/diaria/28079"
+	server := forecastServer(t)
+	broken := false
+
+	cache := &memCache{}
+	client, err := New(Config{
+		AemetApiKey: "test",
+		Cache:       cache,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if broken && strings.Contains(req.URL.Path, "/sh/") {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "200 OK",
+					Header:     http.Header{"Content-Type": []string{"text/html"}},
+					Body:       io.NopCloser(strings.NewReader("<html>Servicio no disponible</html>")),
+				}, nil
+			}
+			return server(req)
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := client.GetForecastFor("28079")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Cache.Stale {
+		t.Error("fresh forecast marked stale")
+	}
+	good, ok := cache.Get(path)
+	if !ok {
+		t.Fatal("forecast not cached")
+	}
+
+	// An undecodable payload is served from the cache and does not replace it
+	broken = true
+	m, err = client.GetForecastFor("28079")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Cache.Stale || m.Nombre != "Madrid" {
+		t.Errorf("forecast = %q, stale %v, want cached Madrid", m.Nombre, m.Cache.Stale)
+	}
+	if entry, _ := cache.Get(path); string(entry.Data) != string(good.Data) {
+		t.Errorf("cache entry replaced with %q", entry.Data)
+	}
+}
+
+func TestStreamStationsSkipsCache(t *testing.T) {
+	const path = "api/valores/climatologicos/inventarioestaciones/todasestaciones"
+	const stations = `[{"indicativo": "3195", "nombre": "MADRID, RETIRO"}, {"indicativo": "3129", "nombre": "MADRID AEROPUERTO"}]`
+
+	cache := &memCache{}
+	config := Config{
+		AemetApiKey: "test",
+		Cache:       cache,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, "/sh/") {
+				return jsonResponse(http.StatusOK, stations), nil
+			}
+			return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/stations"}`), nil
+		})},
+	}
+	client, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := client.FindStations(StationFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 {
+		t.Errorf("streamed %d stations, want 2", len(found))
+	}
+	if _, ok := cache.Get(path); ok {
+		t.Fatal("streamed stations were cached")
+	}
+
+	if _, err := client.GetStations(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(path); !ok {
+		t.Fatal("stations not cached")
+	}
+
+	// Offline clients stream the stations cached by GetStations
+	config.Offline = true
+	offline, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found, err := offline.FindStations(StationFilter{}); err != nil || len(found) != 2 {
+		t.Errorf("offline FindStations() = %d stations, %v, want 2", len(found), err)
+	}
+}
+
+func TestCacheStatusResults(t *testing.T) {
+	cache := &memCache{}
+	online, err := New(Config{
+		AemetApiKey: "test",
+		Cache:       cache,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/sh/radar"):
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "200 OK",
+					Header:     http.Header{"Content-Type": []string{"image/gif"}},
+					Body:       io.NopCloser(strings.NewReader("GIF89a")),
+				}, nil
+			case strings.HasSuffix(req.URL.Path, "/sh/stations"):
+				return jsonResponse(http.StatusOK, `[{"indicativo": "3195", "nombre": "MADRID, RETIRO"}]`), nil
+			case strings.HasSuffix(req.URL.Path, "/sh/uvi"):
+				return jsonResponse(http.StatusOK, "\"ciudad\",\"uvi\"\n\"Madrid\",8\n"), nil
+			case strings.Contains(req.URL.Path, "radar"):
+				return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/radar"}`), nil
+			case strings.Contains(req.URL.Path, "uvi"):
+				return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/uvi"}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/stations"}`), nil
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, err := New(Config{AemetApiKey: "offline", Cache: cache, Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		client *Client
+		stale  bool
+	}{{online, false}, {offline, true}} {
+		img, err := c.client.GetNationalRadar()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Cache.Stale != c.stale {
+			t.Errorf("radar stale = %v, want %v", img.Cache.Stale, c.stale)
+		}
+
+		uv, err := c.client.GetUVForecast(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uv.Cache.Stale != c.stale {
+			t.Errorf("UV forecast stale = %v, want %v", uv.Cache.Stale, c.stale)
+		}
+
+		stations, status, err := c.client.GetStationsWithStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stations) != 1 || status.Stale != c.stale {
+			t.Errorf("%d stations, stale %v, want 1, %v", len(stations), status.Stale, c.stale)
+		}
+	}
+}
+
+func TestCacheFallbackErrors(t *testing.T) {
+	defer func(d time.Duration) { rateLimitBackoff = d }(rateLimitBackoff)
+	rateLimitBackoff = time.Millisecond
+
+	server := forecastServer(t)
+	var fail func() (*http.Response, error)
+	client, err := New(Config{
+		AemetApiKey: "test",
+		Cache:       &memCache{},
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if fail != nil {
+				return fail()
+			}
+			return server(req)
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetForecastFor("28079"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name   string
+		fail   func() (*http.Response, error)
+		cached bool
+	}{
+		{"transport", func() (*http.Response, error) { return nil, errors.New("connection reset") }, true},
+		{"server error", func() (*http.Response, error) {
+			return jsonResponse(http.StatusInternalServerError, `{"descripcion": "Error interno", "estado": 500}`), nil
+		}, true},
+		{"rate limited", func() (*http.Response, error) {
+			return jsonResponse(http.StatusTooManyRequests, `{"descripcion": "Limite de peticiones", "estado": 429}`), nil
+		}, true},
+		{"unauthorized", func() (*http.Response, error) {
+			return jsonResponse(http.StatusUnauthorized, `{"descripcion": "API key invalido", "estado": 401}`), nil
+		}, false},
+		{"not found", func() (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"descripcion": "No hay datos que satisfagan esos criterios", "estado": 404}`), nil
+		}, false},
+	} {
+		fail = c.fail
+		m, err := client.GetForecastFor("28079")
+		if c.cached && (err != nil || !m.Cache.Stale) {
+			t.Errorf("%s: GetForecastFor() error %v, want stale cached forecast", c.name, err)
+		}
+		if !c.cached && err == nil {
+			t.Errorf("%s: GetForecastFor() served the cache, want an error", c.name)
+		}
+	}
+}
+
+func TestCachedDates(t *testing.T) {
+	stored := time.Now().AddDate(0, 0, -3)
+	cache := &memCache{}
+	cache.Set("api/prediccion/especifica/uvi/1", CacheEntry{Data: []byte("\"ciudad\",\"uvi\"\n\"Madrid\",8\n"), Stored: stored})
+	cache.Set("api/incendios/mapasriesgo/previsto/dia/2/area/p", CacheEntry{Data: []byte("GIF89a"), ContentType: "image/gif", Stored: stored})
+	cache.Set("api/incendios/mapasriesgo/estimado/area/p", CacheEntry{Data: []byte("GIF89a"), ContentType: "image/gif", Stored: stored})
+
+	client, err := New(Config{AemetApiKey: "offline", Cache: cache, Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dates are relative to the day the data was retrieved, not today
+	day := func(days int) string {
+		return stored.In(madridLocation()).AddDate(0, 0, days).Format(time.DateOnly)
+	}
+
+	uv, err := client.GetUVForecast(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := uv.Date.Format(time.DateOnly); got != day(1) {
+		t.Errorf("UV forecast date = %s, want %s", got, day(1))
+	}
+
+	forecast, err := client.GetFireRiskForecast(FireRiskPeninsula, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := forecast.Date.Format(time.DateOnly); got != day(2) {
+		t.Errorf("fire risk forecast date = %s, want %s", got, day(2))
+	}
+
+	estimated, err := client.GetFireRiskEstimated(FireRiskPeninsula)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := estimated.Date.Format(time.DateOnly); got != day(0) {
+		t.Errorf("estimated fire risk date = %s, want %s", got, day(0))
+	}
+}
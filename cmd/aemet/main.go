@@ -4,22 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"strings"
+	"path/filepath"
 	"time"
 
 	"github.com/rubiojr/aemet-go"
+	"github.com/rubiojr/aemet-go/replay"
+	"github.com/rubiojr/aemet-go/units"
 	"github.com/urfave/cli/v3"
 )
 
-// formatDate converts date string from API format to a more readable format
-func formatDate(dateStr string) string {
-	// Parse the date (format: "2025-05-20T00:00:00")
-	t, err := time.Parse("2006-01-02T15:04:05", dateStr)
-	if err != nil {
-		return dateStr // Return original if parsing fails
-	}
-
+// formatDate formats a forecast date in a more readable format
+func formatDate(t time.Time) string {
 	// Format as "Monday, Jan 02"
 	return t.Format("Monday, Jan 02")
 }
@@ -50,203 +47,98 @@ func getWindDirectionEmoji(direction string) string {
 	}
 }
 
-// getWeatherEmoji returns an emoji based on weather description and rain probability
-func getWeatherEmoji(desc string, rainProb int) string {
-	desc = strings.ToLower(desc)
+// getWeatherEmoji returns an emoji based on the sky state code and rain probability
+func getWeatherEmoji(skyCode string, rainProb int) string {
 	if rainProb > 70 {
 		return "🌧️" // Rain
 	} else if rainProb > 30 {
 		return "🌦️" // Rain and sun
-	} else if strings.Contains(desc, "tormenta") {
+	}
+
+	sky, ok := aemet.LookupSkyState(skyCode)
+	if !ok {
+		return "☀️" // Default sunny
+	}
+
+	switch sky.Condition {
+	case aemet.ConditionStorm:
 		return "⛈️" // Storm
-	} else if strings.Contains(desc, "nieve") {
+	case aemet.ConditionSnow, aemet.ConditionLightSnow:
 		return "❄️" // Snow
-	} else if strings.Contains(desc, "niebla") {
+	case aemet.ConditionFog, aemet.ConditionMist, aemet.ConditionHaze:
 		return "🌫️" // Fog
-	} else if strings.Contains(desc, "nubos") {
-		if strings.Contains(desc, "poco") {
-			return "🌤️" // Partly cloudy
-		} else if strings.Contains(desc, "muy") {
-			return "☁️" // Very cloudy
-		} else {
-			return "⛅" // Cloudy
-		}
-	} else if strings.Contains(desc, "despejado") {
-		return "☀️" // Sunny
-	} else if strings.Contains(desc, "lluvia") {
-		if strings.Contains(desc, "escasa") {
-			return "🌦️" // Light rain
-		}
+	case aemet.ConditionLightRain:
+		return "🌦️" // Light rain
+	case aemet.ConditionRain:
 		return "🌧️" // Rain
-	} else {
-		return "☀️" // Default sunny
+	case aemet.ConditionPartlyCloudy, aemet.ConditionHighClouds:
+		return "🌤️" // Partly cloudy
+	case aemet.ConditionCloudy:
+		return "⛅" // Cloudy
+	case aemet.ConditionOvercast:
+		return "☁️" // Very cloudy
+	default:
+		return "☀️" // Sunny
 	}
 }
 
-// PeriodData holds weather information for a specific time period
-type PeriodData struct {
-	RainProb  int
-	SkyDesc   string
-	WindDir   string
-	WindSpeed int
-}
-
-// extractPeriodData extracts weather data for different time periods from a day forecast
-func extractPeriodData(day aemet.Dia) (map[string]PeriodData, bool, bool, bool) {
-	// Check if we have detailed periods or just 24h period
-	hasMorningData := false
-	hasAfternoonData := false
-	has24hData := false
-
-	// Map to store period data
-	periodData := make(map[string]PeriodData)
-
-	// Find data for each period
-	for _, prob := range day.ProbPrecipitacion {
-		// For last days which might not have a period field
-		if prob.Periodo == "" {
-			if pd, ok := periodData["default"]; ok {
-				pd.RainProb = prob.Value
-				periodData["default"] = pd
-			} else {
-				periodData["default"] = PeriodData{RainProb: prob.Value}
-			}
-			has24hData = true
-		} else {
-			if pd, ok := periodData[prob.Periodo]; ok {
-				pd.RainProb = prob.Value
-				periodData[prob.Periodo] = pd
-			} else {
-				periodData[prob.Periodo] = PeriodData{RainProb: prob.Value}
-			}
-			if prob.Periodo == "00-12" {
-				hasMorningData = true
-			} else if prob.Periodo == "12-24" {
-				hasAfternoonData = true
-			} else if prob.Periodo == "00-24" {
-				has24hData = true
-			}
-		}
-	}
-
-	for _, sky := range day.EstadoCielo {
-		// For last days which might not have a period field
-		if sky.Periodo == "" {
-			if pd, ok := periodData["default"]; ok {
-				pd.SkyDesc = sky.Descripcion
-				periodData["default"] = pd
-			} else {
-				periodData["default"] = PeriodData{SkyDesc: sky.Descripcion}
-			}
-			has24hData = true
-		} else {
-			if pd, ok := periodData[sky.Periodo]; ok {
-				pd.SkyDesc = sky.Descripcion
-				periodData[sky.Periodo] = pd
-			} else {
-				periodData[sky.Periodo] = PeriodData{SkyDesc: sky.Descripcion}
-			}
-			if sky.Periodo == "00-12" {
-				hasMorningData = true
-			} else if sky.Periodo == "12-24" {
-				hasAfternoonData = true
-			} else if sky.Periodo == "00-24" {
-				has24hData = true
-			}
-		}
-	}
-
-	for _, wind := range day.Viento {
-		// For last days which might not have a period field
-		if wind.Periodo == "" {
-			if pd, ok := periodData["default"]; ok {
-				pd.WindDir = wind.Direccion
-				pd.WindSpeed = wind.Velocidad
-				periodData["default"] = pd
-			} else {
-				periodData["default"] = PeriodData{WindDir: wind.Direccion, WindSpeed: wind.Velocidad}
-			}
-			has24hData = true
-		} else {
-			if pd, ok := periodData[wind.Periodo]; ok {
-				pd.WindDir = wind.Direccion
-				pd.WindSpeed = wind.Velocidad
-				periodData[wind.Periodo] = pd
-			} else {
-				periodData[wind.Periodo] = PeriodData{WindDir: wind.Direccion, WindSpeed: wind.Velocidad}
-			}
-			if wind.Periodo == "00-12" {
-				hasMorningData = true
-			} else if wind.Periodo == "12-24" {
-				hasAfternoonData = true
-			} else if wind.Periodo == "00-24" {
-				has24hData = true
-			}
-		}
-	}
-
-	return periodData, hasMorningData, hasAfternoonData, has24hData
-}
+var (
+	morning   = aemet.Period{Start: 0, End: 12}
+	afternoon = aemet.Period{Start: 12, End: 24}
+	allDay    = aemet.Period{Start: 0, End: 24}
+)
 
 // displayPeriod prints weather information for a specific time period
-func displayPeriod(periodName string, data PeriodData) {
-	emoji := getWeatherEmoji(data.SkyDesc, data.RainProb)
+func displayPeriod(periodName string, data units.PeriodForecast) {
+	emoji := getWeatherEmoji(data.SkyCode, data.RainProb.Value)
 
 	fmt.Printf("%s: %s ", periodName, emoji)
 	if data.SkyDesc != "" {
 		fmt.Printf("%s", data.SkyDesc)
 	}
-	if data.RainProb > 0 {
-		fmt.Printf(" (💧 %d%%)", data.RainProb)
+	if data.RainProb.Value > 0 {
+		fmt.Printf(" (💧 %d%%)", data.RainProb.Value)
 	}
 
 	// Show wind information with directional emoji
-	if data.WindDir != "" && data.WindSpeed > 0 {
+	if data.WindDir != "" && speedValue(data.WindSpeed) > 0 {
 		windEmoji := getWindDirectionEmoji(data.WindDir)
-		fmt.Printf(" %s %s at %d km/h", windEmoji, data.WindDir, data.WindSpeed)
+		fmt.Printf(" %s %s at %s", windEmoji, data.WindDir, data.WindSpeed)
 	}
 	fmt.Println()
 }
 
-// get24hPeriodData combines data from 00-24 period and default (no period) data
-func get24hPeriodData(periodData map[string]PeriodData) PeriodData {
-	// Start with 00-24 data if available
-	data := periodData["00-24"]
-
-	// If default (no period) data exists, use it for missing values
-	if defaultData, ok := periodData["default"]; ok {
-		if data.RainProb == 0 && defaultData.RainProb > 0 {
-			data.RainProb = defaultData.RainProb
-		}
-		if data.SkyDesc == "" && defaultData.SkyDesc != "" {
-			data.SkyDesc = defaultData.SkyDesc
-		}
-		if data.WindDir == "" && defaultData.WindDir != "" {
-			data.WindDir = defaultData.WindDir
-			data.WindSpeed = defaultData.WindSpeed
-		}
+// speedValue returns the value of s, or 0 if AEMET did not provide it
+func speedValue(s *units.Speed) float64 {
+	if s == nil {
+		return 0
 	}
+	return s.Value
+}
 
-	return data
+// formatTemperature formats t, or "?" if AEMET did not provide it
+func formatTemperature(t *units.Temperature) string {
+	if t == nil {
+		return "?"
+	}
+	return t.String()
 }
 
 // displayDayForecast displays the weather forecast for a single day
-func displayDayForecast(day aemet.Dia) {
+func displayDayForecast(day *units.Day) {
 	// Format date nicely
-	formattedDate := formatDate(day.Fecha)
+	formattedDate := formatDate(day.Dia.Fecha)
 
 	// Print date and temperature range
-	fmt.Printf("\n📅 %s (🌡️ %d°C to %d°C)\n", formattedDate, day.Temperatura.Minima, day.Temperatura.Maxima)
-
-	// Extract period data
-	periodData, hasMorningData, hasAfternoonData, has24hData := extractPeriodData(day)
+	fmt.Printf("\n📅 %s (🌡️ %s to %s)\n", formattedDate, formatTemperature(day.Temperature.Min), formatTemperature(day.Temperature.Max))
 
 	// Display weather info based on available data
+	morningData, hasMorningData := day.Period(morning)
+	afternoonData, hasAfternoonData := day.Period(afternoon)
 	if hasMorningData && hasAfternoonData {
-		displayPeriod("Morning (00-12h)", periodData["00-12"])
-		displayPeriod("Afternoon (12-24h)", periodData["12-24"])
-	} else if has24hData {
-		data := get24hPeriodData(periodData)
+		displayPeriod("Morning (00-12h)", morningData)
+		displayPeriod("Afternoon (12-24h)", afternoonData)
+	} else if data, ok := day.Period(allDay); ok {
 		displayPeriod("All day", data)
 	}
 }
@@ -256,22 +148,27 @@ func printForecastHeader(mun *aemet.Municipality) {
 	currentTime := time.Now().Format("Monday, January 02 at 15:04")
 	fmt.Printf("\n🌤️  Weather forecast for %s (%s)\n", mun.Nombre, mun.Provincia)
 	fmt.Printf("📊 Forecast updated on %s\n", currentTime)
+	if mun.Cache.Stale {
+		fmt.Printf("⚠️  Offline: showing data cached %s ago\n", mun.Cache.Age.Round(time.Minute))
+	}
 	fmt.Println("==============================================")
 }
 
 // displayForecast shows the weather forecast for the given municipality
-func displayForecast(mun *aemet.Municipality) {
+func displayForecast(mun *aemet.Municipality, sys units.System) {
+	forecast := units.Convert(mun, sys)
+
 	// Print forecast header
 	printForecastHeader(mun)
 
 	// Display forecast for each day
-	for _, day := range mun.Prediccion.Dia {
-		displayDayForecast(day)
+	for i := range forecast.Days {
+		displayDayForecast(&forecast.Days[i])
 	}
 }
 
-// getDayForecastSummary returns a one-line weather summary for a municipality by name
-func getDayForecastSummary(client *aemet.Client, municipalityName string) (string, error) {
+// resolveMunicipalityID returns the ID of the first municipality matching name
+func resolveMunicipalityID(municipalityName string) (string, error) {
 	municipalities, err := aemet.FindMunicipalitiesByPartialName(municipalityName)
 	if err != nil {
 		return "", fmt.Errorf("error finding municipalities: %v", err)
@@ -281,74 +178,52 @@ func getDayForecastSummary(client *aemet.Client, municipalityName string) (strin
 		return "", fmt.Errorf("no municipalities found matching '%s'", municipalityName)
 	}
 
-	selectedMuni := municipalities[0]
-	mun, err := client.GetForecastFor(selectedMuni.ID)
-	if err != nil {
-		return "", fmt.Errorf("error getting weather data: %v", err)
-	}
-
-	return buildWeatherSummary(mun)
-}
-
-// getDayForecastSummaryByID returns a one-line weather summary for a municipality by ID
-func getDayForecastSummaryByID(client *aemet.Client, municipalityID string) (string, error) {
-	mun, err := client.GetForecastFor(municipalityID)
-	if err != nil {
-		return "", fmt.Errorf("error getting weather data: %v", err)
-	}
-
-	return buildWeatherSummary(mun)
+	return municipalities[0].ID, nil
 }
 
 // buildWeatherSummary creates a weather summary string from municipality data
-func buildWeatherSummary(mun *aemet.Municipality) (string, error) {
-	if len(mun.Prediccion.Dia) == 0 {
+// for the day of now
+func buildWeatherSummary(mun *aemet.Municipality, sys units.System, now time.Time) (string, error) {
+	today, ok := units.Convert(mun, sys).Day(now)
+	if !ok {
 		return "", fmt.Errorf("no forecast data available")
 	}
 
-	today := mun.Prediccion.Dia[0]
-	periodData, hasMorningData, hasAfternoonData, has24hData := extractPeriodData(today)
-
-	var skyDesc string
-	var rainProb int
-	var windDir string
-	var windSpeed int
+	var data units.PeriodForecast
 
+	morningData, hasMorningData := today.Period(morning)
+	afternoonData, hasAfternoonData := today.Period(afternoon)
 	if hasMorningData && hasAfternoonData {
-		morningData := periodData["00-12"]
-		afternoonData := periodData["12-24"]
-		if morningData.RainProb > afternoonData.RainProb {
-			rainProb = morningData.RainProb
-			skyDesc = morningData.SkyDesc
+		if morningData.RainProb.Value > afternoonData.RainProb.Value {
+			data.RainProb = morningData.RainProb
+			data.SkyCode = morningData.SkyCode
+			data.SkyDesc = morningData.SkyDesc
 		} else {
-			rainProb = afternoonData.RainProb
-			skyDesc = afternoonData.SkyDesc
+			data.RainProb = afternoonData.RainProb
+			data.SkyCode = afternoonData.SkyCode
+			data.SkyDesc = afternoonData.SkyDesc
 		}
-		if morningData.WindSpeed > afternoonData.WindSpeed {
-			windDir = morningData.WindDir
-			windSpeed = morningData.WindSpeed
+		if speedValue(morningData.WindSpeed) > speedValue(afternoonData.WindSpeed) {
+			data.WindDir = morningData.WindDir
+			data.WindSpeed = morningData.WindSpeed
 		} else {
-			windDir = afternoonData.WindDir
-			windSpeed = afternoonData.WindSpeed
+			data.WindDir = afternoonData.WindDir
+			data.WindSpeed = afternoonData.WindSpeed
 		}
-	} else if has24hData {
-		data := get24hPeriodData(periodData)
-		skyDesc = data.SkyDesc
-		rainProb = data.RainProb
-		windDir = data.WindDir
-		windSpeed = data.WindSpeed
+	} else if allDayData, ok := today.Period(allDay); ok {
+		data = allDayData
 	}
 
-	emoji := getWeatherEmoji(skyDesc, rainProb)
-	summary := fmt.Sprintf("%s %s: %s %d°C-%d°C", emoji, mun.Nombre, skyDesc, today.Temperatura.Minima, today.Temperatura.Maxima)
+	emoji := getWeatherEmoji(data.SkyCode, data.RainProb.Value)
+	summary := fmt.Sprintf("%s %s: %s %s-%s", emoji, mun.Nombre, data.SkyDesc, formatTemperature(today.Temperature.Min), formatTemperature(today.Temperature.Max))
 
-	if rainProb > 0 {
-		summary += fmt.Sprintf(" (💧 %d%%)", rainProb)
+	if data.RainProb.Value > 0 {
+		summary += fmt.Sprintf(" (💧 %d%%)", data.RainProb.Value)
 	}
 
-	if windDir != "" && windSpeed > 0 {
-		windEmoji := getWindDirectionEmoji(windDir)
-		summary += fmt.Sprintf(" %s %d km/h", windEmoji, windSpeed)
+	if data.WindDir != "" && speedValue(data.WindSpeed) > 0 {
+		windEmoji := getWindDirectionEmoji(data.WindDir)
+		summary += fmt.Sprintf(" %s %s", windEmoji, data.WindSpeed)
 	}
 
 	return summary, nil
@@ -363,7 +238,12 @@ func dayCommand(ctx context.Context, cmd *cli.Command) error {
 
 	useIDs := cmd.Bool("use-ids")
 
-	client, err := aemet.NewWithDefaults()
+	sys, err := units.ParseSystem(cmd.String("units"))
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(cmd)
 	if err != nil {
 		return fmt.Errorf("error creating client: %v", err)
 	}
@@ -371,26 +251,82 @@ func dayCommand(ctx context.Context, cmd *cli.Command) error {
 	fmt.Printf("🌤️  El tiempo hoy\n")
 	fmt.Println("==============================================")
 
+	// Resolve names first, so forecasts can be fetched in a single batch
+	ids := make([]string, 0, len(cities))
+	names := make([]string, 0, len(cities))
 	for _, city := range cities {
-		var summary string
-		var err error
+		id := city
+		if !useIDs {
+			id, err = resolveMunicipalityID(city)
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", city, err)
+				continue
+			}
+		}
+		ids = append(ids, id)
+		names = append(names, city)
+	}
 
-		if useIDs {
-			summary, err = getDayForecastSummaryByID(client, city)
-		} else {
-			summary, err = getDayForecastSummary(client, city)
+	for i, result := range client.GetForecasts(ctx, ids) {
+		if result.Err != nil {
+			fmt.Printf("❌ %s: error getting weather data: %v\n", names[i], result.Err)
+			continue
 		}
 
+		summary, err := buildWeatherSummary(result.Forecast, sys, time.Now())
 		if err != nil {
-			fmt.Printf("❌ %s: %v\n", city, err)
+			fmt.Printf("❌ %s: %v\n", names[i], err)
 			continue
 		}
+		if result.Forecast.Cache.Stale {
+			summary += fmt.Sprintf(" (cached %s ago)", result.Forecast.Cache.Age.Round(time.Minute))
+		}
 		fmt.Println(summary)
 	}
 
 	return nil
 }
 
+// newClient creates the AEMET client, recording or replaying API exchanges
+// when the --record or --replay flags are set, and serving cached data only
+// when --offline is set. Replayed data is not cached, nor served from the cache.
+func newClient(cmd *cli.Command) (*aemet.Client, error) {
+	config := aemet.Config{Offline: cmd.Bool("offline")}
+
+	// Keep the last data retrieved, to fall back on when AEMET can't be reached.
+	// Fixtures must neither overwrite it nor be masked by it.
+	if dir, err := os.UserCacheDir(); err == nil && cmd.String("replay") == "" {
+		if cache, err := aemet.NewFileCache(filepath.Join(dir, "aemet")); err == nil {
+			config.Cache = cache
+		}
+	}
+
+	switch {
+	case cmd.String("replay") != "":
+		r, err := replay.NewReplayer(cmd.String("replay"))
+		if err != nil {
+			return nil, err
+		}
+		config.HTTPClient = &http.Client{Transport: r}
+		if os.Getenv(aemet.EnvAemetApiKey) == "" {
+			// Fixtures are recorded without the API key, any value works
+			config.AemetApiKey = "replay"
+		}
+	case config.Offline:
+		if os.Getenv(aemet.EnvAemetApiKey) == "" {
+			// No request is made, so the API key is not needed
+			config.AemetApiKey = "offline"
+		}
+	case cmd.String("record") != "":
+		config.HTTPClient = &http.Client{
+			Transport: &replay.Recorder{Dir: cmd.String("record")},
+			Timeout:   30 * time.Second,
+		}
+	}
+
+	return aemet.New(config)
+}
+
 // forecastCommand handles the forecast subcommand
 func forecastCommand(ctx context.Context, cmd *cli.Command) error {
 	// Get the municipality name from args
@@ -399,8 +335,13 @@ func forecastCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("municipality name is required")
 	}
 
+	sys, err := units.ParseSystem(cmd.String("units"))
+	if err != nil {
+		return err
+	}
+
 	// Create the AEMET client
-	client, err := aemet.NewWithDefaults()
+	client, err := newClient(cmd)
 	if err != nil {
 		return fmt.Errorf("error creating client: %v", err)
 	}
@@ -445,7 +386,7 @@ func forecastCommand(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Display the forecast
-	displayForecast(mun)
+	displayForecast(mun, sys)
 	return nil
 }
 
@@ -454,6 +395,26 @@ func main() {
 	app := &cli.Command{
 		Name:  "aemet",
 		Usage: "AEMET weather data CLI tool",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "units",
+				Aliases: []string{"u"},
+				Usage:   "Unit system: metric, si, imperial, nautical or beaufort",
+				Value:   "metric",
+			},
+			&cli.BoolFlag{
+				Name:  "offline",
+				Usage: "Never access the network, show the last cached data",
+			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: "Record API exchanges as fixtures into `DIR`",
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: "Serve API exchanges from fixtures in `DIR` instead of the network",
+			},
+		},
 		Commands: []*cli.Command{
 			{
 				Name:    "forecast",
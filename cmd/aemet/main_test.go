@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubiojr/aemet-go"
+	"github.com/rubiojr/aemet-go/replay"
+	"github.com/rubiojr/aemet-go/units"
+	"github.com/urfave/cli/v3"
+)
+
+// forecastFixture is the hand-built Madrid forecast shared with the library tests
+const forecastFixture = "../../testdata/prediccion_diaria_28079.json"
+
+func TestBuildWeatherSummary(t *testing.T) {
+	b, err := os.ReadFile(forecastFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var forecasts []*aemet.Municipality
+	if err := json.Unmarshal(b, &forecasts); err != nil {
+		t.Fatal(err)
+	}
+	mun := forecasts[0]
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 5, 21, 9, 0, 0, 0, madrid)
+
+	tests := []struct {
+		sys  units.System
+		want string
+	}{
+		{units.Metric, "⛅ Madrid: Nuboso 11 °C-27 °C (💧 10%) 💨 20 km/h"},
+		{units.Imperial, "⛅ Madrid: Nuboso 51.8 °F-80.6 °F (💧 10%) 💨 12.4 mph"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sys.Name, func(t *testing.T) {
+			got, err := buildWeatherSummary(mun, tt.sys, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("summary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := buildWeatherSummary(mun, units.Metric, now.AddDate(0, 1, 0)); err == nil {
+		t.Error("built a summary for a day without forecast")
+	}
+}
+
+// recordForecast records the Madrid forecast exchanges into a directory,
+// with a stand-in for the API serving forecastFixture
+func recordForecast(t *testing.T) string {
+	t.Helper()
+
+	fixture, err := os.ReadFile(forecastFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	client, err := aemet.New(aemet.Config{
+		AemetApiKey: "test",
+		HTTPClient: &http.Client{Transport: &replay.Recorder{
+			Dir: dir,
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				body := `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/forecast"}`
+				if strings.Contains(req.URL.Path, "/sh/") {
+					body = string(fixture)
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			}),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetForecastFor("28079"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientReplayWithoutCache(t *testing.T) {
+	fixtures := recordForecast(t)
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+
+	cmd := &cli.Command{
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "offline"},
+			&cli.StringFlag{Name: "record"},
+			&cli.StringFlag{Name: "replay"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			client, err := newClient(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.GetForecastFor("28079")
+			return err
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"aemet", "--replay", fixtures}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("replay wrote to the cache directory: %v", entries)
+	}
+}
@@ -0,0 +1,33 @@
+package aemet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when AEMET answers a request without data, for
+// instance because the API key is invalid, there is no data for the request
+// or the rate limit was exceeded.
+type APIError struct {
+	// Status is the "estado" code AEMET returns, usually mirroring an HTTP
+	// status such as 401, 404 or 429. It is zero if AEMET did not send one.
+	Status int
+	// Description is the "descripcion" message AEMET returns.
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("no data URL in response: %s", e.Description)
+}
+
+// isTransient reports whether err may go away by itself, so cached data is
+// worth serving instead: transport failures, server errors and rate limiting.
+// Other API errors, such as an invalid key or a missing resource, are definitive.
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return apiErr.Status == http.StatusTooManyRequests || apiErr.Status >= http.StatusInternalServerError
+}
@@ -0,0 +1,322 @@
+package aemet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Variable represents a measured variable of a special network dataset
+type Variable struct {
+	Name string
+	// Unit as written by AEMET in the column header, e.g. "DU" or "µg/m3".
+	// Empty when the header does not state one.
+	Unit string
+}
+
+// Sample represents one row of a special network dataset.
+// Values are in the same order as the series variables.
+type Sample struct {
+	// Time is the observation time in UTC. It is the zero time for rows
+	// not keyed by time, such as the levels of an ozone sounding.
+	Time   time.Time
+	Values []Float
+}
+
+// SpecialSeries represents the measurements of a station of the special network
+type SpecialSeries struct {
+	// Station is empty when the dataset does not identify stations.
+	Station   string
+	Variables []Variable
+	Samples   []Sample
+	// Cache tells whether the dataset was served from the client cache.
+	Cache CacheStatus
+}
+
+// Values returns the values of a variable, matched case-insensitively,
+// in sample order. It returns nil if the series has no such variable.
+func (s *SpecialSeries) Values(name string) []Float {
+	for i, v := range s.Variables {
+		if strings.EqualFold(v.Name, name) {
+			values := make([]Float, len(s.Samples))
+			for j, sample := range s.Samples {
+				if i < len(sample.Values) {
+					values[j] = sample.Values[i]
+				}
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+var unitRe = regexp.MustCompile(`^(.*?)\s*[(\[]([^)\]]*)[)\]]\s*$`)
+
+// parseVariable splits a column header like "O3 (µg/m3)" into name and unit
+func parseVariable(header string) Variable {
+	header = strings.TrimSpace(header)
+	if m := unitRe.FindStringSubmatch(header); m != nil {
+		return Variable{Name: m[1], Unit: strings.TrimSpace(m[2])}
+	}
+	return Variable{Name: header}
+}
+
+type columnKind int
+
+const (
+	columnValue columnKind = iota
+	columnStation
+	columnDate
+	columnTime
+)
+
+func classifyColumn(header string) columnKind {
+	switch strings.ToLower(parseVariable(header).Name) {
+	case "estacion", "estación", "indicativo", "nombre", "station":
+		return columnStation
+	case "fecha", "dia", "día", "date", "fecha_hora", "fechahora":
+		return columnDate
+	case "hora", "time", "hour":
+		return columnTime
+	}
+	return columnValue
+}
+
+var specialTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"2006-01-02",
+	"02/01/2006",
+	"02-01-2006",
+	"20060102",
+}
+
+// parseSpecialTime parses a date, optionally followed by a clock time,
+// such as "2024-06-01", "01/06/2024 12:00" or "2024-06-01 12".
+func parseSpecialTime(date, clock string) (time.Time, error) {
+	s := strings.TrimSpace(date)
+	if clock = strings.TrimSpace(clock); clock != "" {
+		if !strings.Contains(clock, ":") {
+			// Hours, or HHMM
+			switch len(clock) {
+			case 1:
+				clock = "0" + clock + ":00"
+			case 2:
+				clock += ":00"
+			case 4:
+				clock = clock[:2] + ":" + clock[2:]
+			}
+		}
+		s += " " + clock
+	}
+
+	for _, layout := range specialTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q", s)
+}
+
+// splitFields splits a delimited line for header detection, trimming
+// spaces and quotes around each field
+func splitFields(line string, sep rune) []string {
+	fields := strings.Split(line, string(sep))
+	for i, f := range fields {
+		fields[i] = strings.Trim(strings.TrimSpace(f), `"`)
+	}
+	return fields
+}
+
+// isHeaderLine reports whether fields, split with sep, can be the header of
+// the rows that follow: it has at least two named columns, not counting empty
+// trailing fields left by a trailing delimiter, and the next line has the same
+// number of fields, at least one of them a number.
+func isHeaderLine(fields []string, next string, sep rune) bool {
+	named, text := 0, false
+	for _, f := range fields {
+		if f == "" {
+			continue
+		}
+		named++
+		if v, err := parseFloat(f); err != nil || !v.Valid {
+			text = true
+		}
+	}
+	if named < 2 || !text {
+		return false
+	}
+
+	row := splitFields(next, sep)
+	if len(row) != len(fields) {
+		return false
+	}
+	for _, f := range row {
+		if v, err := parseFloat(f); err == nil && v.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// findHeader returns the index of the header line and its delimiter.
+// Preamble lines, even those containing a delimiter, are skipped because
+// they are not followed by rows of the same shape.
+func findHeader(lines []string) (int, rune, bool) {
+	for i, line := range lines {
+		next := ""
+		for _, l := range lines[i+1:] {
+			if strings.TrimSpace(l) != "" {
+				next = l
+				break
+			}
+		}
+
+		for _, sep := range []rune{';', '\t', ','} {
+			fields := splitFields(line, sep)
+			if len(fields) < 2 {
+				continue
+			}
+			if isHeaderLine(fields, next, sep) {
+				return i, sep, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// parseSpecialSeries parses the delimited text AEMET serves for special network
+// products. Lines before the header, the first delimited line followed by rows
+// of the same shape, are ignored. Rows are grouped by station when the dataset
+// has a station column.
+func parseSpecialSeries(text string) ([]*SpecialSeries, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(text, "\n")
+	header, comma, ok := findHeader(lines)
+	if !ok {
+		return nil, fmt.Errorf("error parsing special network data: no header found")
+	}
+
+	r := csv.NewReader(strings.NewReader(strings.Join(lines[header:], "\n")))
+	r.Comma = comma
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	columns, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing special network data: %w", err)
+	}
+	// A trailing delimiter leaves an empty last column
+	for len(columns) > 0 && strings.TrimSpace(columns[len(columns)-1]) == "" {
+		columns = columns[:len(columns)-1]
+	}
+
+	kinds := make([]columnKind, len(columns))
+	var variables []Variable
+	for i, col := range columns {
+		kinds[i] = classifyColumn(col)
+		if kinds[i] == columnValue {
+			variables = append(variables, parseVariable(col))
+		}
+	}
+
+	var series []*SpecialSeries
+	byStation := map[string]*SpecialSeries{}
+	for {
+		fields, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing special network data: %w", err)
+		}
+
+		var station, date, clock string
+		sample := Sample{Values: make([]Float, 0, len(variables))}
+		for i, kind := range kinds {
+			var field string
+			if i < len(fields) {
+				field = strings.TrimSpace(fields[i])
+			}
+			switch kind {
+			case columnStation:
+				station = field
+			case columnDate:
+				date = field
+			case columnTime:
+				clock = field
+			default:
+				// Missing or non-numeric values are left invalid
+				v, _ := parseFloat(field)
+				sample.Values = append(sample.Values, v)
+			}
+		}
+		if date != "" {
+			if sample.Time, err = parseSpecialTime(date, clock); err != nil {
+				return nil, fmt.Errorf("error parsing special network data: %w", err)
+			}
+		}
+
+		s, ok := byStation[station]
+		if !ok {
+			s = &SpecialSeries{Station: station, Variables: variables}
+			byStation[station] = s
+			series = append(series, s)
+		}
+		s.Samples = append(s.Samples, sample)
+	}
+
+	return series, nil
+}
+
+func (c *Client) getSpecialSeries(path string) ([]*SpecialSeries, error) {
+	text, status, err := c.getRedirTextWithRetry(path)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	series, err := parseSpecialSeries(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range series {
+		s.Cache = status
+	}
+	return series, nil
+}
+
+// GetOzoneSoundings retrieves the latest ozone sounding of a station, as ozone
+// and meteorological variables per level. Samples are ordered by level and carry
+// no time.
+func (c *Client) GetOzoneSoundings(station string) ([]*SpecialSeries, error) {
+	return c.getSpecialSeries(fmt.Sprintf("api/red/especial/perfilozono/estacion/%s", url.PathEscape(station)))
+}
+
+// GetTotalOzone retrieves the total ozone column measured by the ozone stations.
+func (c *Client) GetTotalOzone() ([]*SpecialSeries, error) {
+	return c.getSpecialSeries("api/red/especial/ozono")
+}
+
+// GetBackgroundPollution retrieves the background pollution (contaminación de fondo)
+// measurements of an EMEP/VAG/CAMP station.
+func (c *Client) GetBackgroundPollution(station string) ([]*SpecialSeries, error) {
+	return c.getSpecialSeries(fmt.Sprintf("api/red/especial/contaminacionfondo/estacion/%s", url.PathEscape(station)))
+}
+
+// GetSolarRadiation retrieves the solar radiation measured by the radiation network.
+func (c *Client) GetSolarRadiation() ([]*SpecialSeries, error) {
+	return c.getSpecialSeries("api/red/especial/radiacion")
+}
@@ -0,0 +1,152 @@
+package aemet
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseSpecialSeries(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		stations  []string
+		variables []Variable
+		times     []time.Time
+		values    [][]Float
+	}{
+		{
+			name: "ozone sounding",
+			text: "Sondeo de ozono de Madrid/Barajas (08221)\n" +
+				"Lanzamiento: 2024-06-05 11:30 UTC\n" +
+				"\n" +
+				"Presion (hPa);Altitud (m);Temperatura (C);Humedad (%);Ozono (mPa)\n" +
+				"944,1;609;24,3;31;3,52\n" +
+				"850,0;1490;16,8;45;4,10\n" +
+				"500,0;5790;-12,4;;2,95\n",
+			stations: []string{""},
+			variables: []Variable{
+				{"Presion", "hPa"}, {"Altitud", "m"}, {"Temperatura", "C"}, {"Humedad", "%"}, {"Ozono", "mPa"},
+			},
+			times: []time.Time{{}, {}, {}},
+			values: [][]Float{
+				{NewFloat(944.1), NewFloat(609), NewFloat(24.3), NewFloat(31), NewFloat(3.52)},
+				{NewFloat(850), NewFloat(1490), NewFloat(16.8), NewFloat(45), NewFloat(4.1)},
+				{NewFloat(500), NewFloat(5790), NewFloat(-12.4), {}, NewFloat(2.95)},
+			},
+		},
+		{
+			name: "total ozone",
+			text: `"Indicativo";"Fecha";"Ozono total (DU)"` + "\n" +
+				`"08221";"2024-06-04";"318"` + "\n" +
+				`"C447A";"2024-06-04";"296"` + "\n" +
+				`"08221";"2024-06-05";"322"` + "\n",
+			stations:  []string{"08221", "C447A"},
+			variables: []Variable{{"Ozono total", "DU"}},
+			times: []time.Time{
+				time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC),
+			},
+			values: [][]Float{{NewFloat(318)}, {NewFloat(322)}, {NewFloat(296)}},
+		},
+		{
+			name: "background pollution",
+			text: "Estación: Niembro (EMEP/VAG/CAMP)\n" +
+				"Fecha;Hora;O3 (µg/m3);NO2 (µg/m3);PM10 (µg/m3)\n" +
+				"01/06/2024;00;62;3,1;12\n" +
+				"01/06/2024;01;58;n/d;11\n",
+			stations:  []string{""},
+			variables: []Variable{{"O3", "µg/m3"}, {"NO2", "µg/m3"}, {"PM10", "µg/m3"}},
+			times: []time.Time{
+				time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 1, 1, 0, 0, 0, time.UTC),
+			},
+			values: [][]Float{
+				{NewFloat(62), NewFloat(3.1), NewFloat(12)},
+				{NewFloat(58), {}, NewFloat(11)},
+			},
+		},
+		{
+			name: "solar radiation",
+			text: "Red de radiación, datos provisionales\r\n" +
+				"Estación;Fecha;Hora;Global (W/m2);Difusa (W/m2);\r\n" +
+				"Madrid;2024-06-01;12;845,2;120,4;\r\n" +
+				"Madrid;2024-06-01;13;;118,0;\r\n",
+			stations:  []string{"Madrid"},
+			variables: []Variable{{"Global", "W/m2"}, {"Difusa", "W/m2"}},
+			times: []time.Time{
+				time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+			values: [][]Float{
+				{NewFloat(845.2), NewFloat(120.4)},
+				{{}, NewFloat(118)},
+			},
+		},
+		{
+			name: "trailing delimiter",
+			text: "Fecha;Hora;Global (W/m2);\n" +
+				"2024-06-01;12;845,2;\n",
+			stations:  []string{""},
+			variables: []Variable{{"Global", "W/m2"}},
+			times:     []time.Time{time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+			values:    [][]Float{{NewFloat(845.2)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := parseSpecialSeries(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var stations []string
+			var times []time.Time
+			var values [][]Float
+			for _, s := range series {
+				stations = append(stations, s.Station)
+				if !slices.Equal(s.Variables, tt.variables) {
+					t.Errorf("station %q variables = %+v, want %+v", s.Station, s.Variables, tt.variables)
+				}
+				for _, sample := range s.Samples {
+					times = append(times, sample.Time)
+					values = append(values, sample.Values)
+				}
+			}
+
+			if !slices.Equal(stations, tt.stations) {
+				t.Errorf("stations = %q, want %q", stations, tt.stations)
+			}
+			if !slices.EqualFunc(times, tt.times, time.Time.Equal) {
+				t.Errorf("times = %v, want %v", times, tt.times)
+			}
+			if !slices.EqualFunc(values, tt.values, slices.Equal) {
+				t.Errorf("values = %+v, want %+v", values, tt.values)
+			}
+		})
+	}
+}
+
+func TestParseSpecialSeriesErrors(t *testing.T) {
+	if _, err := parseSpecialSeries("Sin datos disponibles, inténtelo más tarde\n"); err == nil {
+		t.Error("parsed a payload without header")
+	}
+	if series, err := parseSpecialSeries(" \n"); err != nil || series != nil {
+		t.Errorf("empty payload = %v, %v", series, err)
+	}
+}
+
+func TestSpecialSeriesValues(t *testing.T) {
+	series, err := parseSpecialSeries("Fecha;O3 (µg/m3);NO2 (µg/m3)\n2024-06-01;62;3\n2024-06-02;58;4\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := series[0].Values("no2"); !slices.Equal(got, []Float{NewFloat(3), NewFloat(4)}) {
+		t.Errorf("Values(no2) = %+v", got)
+	}
+	if got := series[0].Values("SO2"); got != nil {
+		t.Errorf("Values(SO2) = %+v, want nil", got)
+	}
+}
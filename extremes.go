@@ -0,0 +1,202 @@
+package aemet
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ExtremeParameter is the climatological parameter of a station's extreme values
+type ExtremeParameter string
+
+const (
+	ExtremePrecipitation ExtremeParameter = "P"
+	ExtremeTemperature   ExtremeParameter = "T"
+	ExtremeWind          ExtremeParameter = "V"
+)
+
+// String returns the name of the parameter
+func (p ExtremeParameter) String() string {
+	switch p {
+	case ExtremePrecipitation:
+		return "precipitación"
+	case ExtremeTemperature:
+		return "temperatura"
+	case ExtremeWind:
+		return "viento"
+	default:
+		return string(p)
+	}
+}
+
+// Extreme represents a record value and the day it was observed.
+// Value is invalid when the station has no record; Date is the zero time
+// when AEMET does not give the day.
+type Extreme struct {
+	Value Float
+	Date  time.Time
+}
+
+// MonthlyExtremes represents the records of a calendar month
+type MonthlyExtremes struct {
+	Month time.Month
+	// Max is the highest value on record: maximum temperature, daily
+	// precipitation or wind gust.
+	Max Extreme
+	// Min is the lowest minimum temperature on record. It is only available
+	// for temperature.
+	Min Extreme
+}
+
+// StationExtremes represents the climatological extreme values of a station.
+// Temperatures are in ºC, precipitation in mm and wind gusts in m/s.
+type StationExtremes struct {
+	Station   string
+	Name      string
+	Location  string
+	Parameter ExtremeParameter
+	// Months holds the records for January to December, in order.
+	Months []MonthlyExtremes
+	// Cache tells whether the records were served from the client cache.
+	Cache CacheStatus
+}
+
+// extremesData is the raw payload: one entry per month followed by the
+// annual value, with temperatures and precipitation in tenths.
+type extremesData struct {
+	Indicativo string `json:"indicativo"`
+	Nombre     string `json:"nombre"`
+	Ubicacion  string `json:"ubicacion"`
+
+	TemMax  []Float `json:"temMax"`
+	DiaMax  []Int   `json:"diaMax"`
+	AnioMax []Int   `json:"anioMax"`
+	TemMin  []Float `json:"temMin"`
+	DiaMin  []Int   `json:"diaMin"`
+	AnioMin []Int   `json:"anioMin"`
+
+	PrecMaxDia []Float `json:"precMaxDia"`
+	DiaMaxDia  []Int   `json:"diaMaxDia"`
+	AnioMaxDia []Int   `json:"anioMaxDia"`
+
+	RachMax     []Float `json:"rachMax"`
+	DiaRachMax  []Int   `json:"diaRachMax"`
+	AnioRachMax []Int   `json:"anioRachMax"`
+}
+
+// monthExtreme builds the extreme of month m (0-based) from the parallel
+// value, day and year arrays, dividing the value by scale.
+func monthExtreme(m int, values []Float, days, years []Int, scale float64) Extreme {
+	var e Extreme
+	if m >= len(values) || !values[m].Valid {
+		return e
+	}
+	e.Value = NewFloat(values[m].Value / scale)
+	if m < len(days) && m < len(years) && days[m].Valid && years[m].Valid {
+		e.Date = time.Date(years[m].Value, time.Month(m+1), days[m].Value, 0, 0, 0, 0, madridLocation())
+	}
+	return e
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StationExtremes) UnmarshalJSON(b []byte) error {
+	var d extremesData
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+
+	s.Station, s.Name, s.Location = d.Indicativo, d.Nombre, d.Ubicacion
+	switch {
+	case d.TemMax != nil || d.TemMin != nil:
+		s.Parameter = ExtremeTemperature
+	case d.PrecMaxDia != nil:
+		s.Parameter = ExtremePrecipitation
+	case d.RachMax != nil:
+		s.Parameter = ExtremeWind
+	}
+
+	s.Months = make([]MonthlyExtremes, 12)
+	for m := range s.Months {
+		me := MonthlyExtremes{Month: time.Month(m + 1)}
+		switch s.Parameter {
+		case ExtremeTemperature:
+			me.Max = monthExtreme(m, d.TemMax, d.DiaMax, d.AnioMax, 10)
+			me.Min = monthExtreme(m, d.TemMin, d.DiaMin, d.AnioMin, 10)
+		case ExtremePrecipitation:
+			me.Max = monthExtreme(m, d.PrecMaxDia, d.DiaMaxDia, d.AnioMaxDia, 10)
+		case ExtremeWind:
+			me.Max = monthExtreme(m, d.RachMax, d.DiaRachMax, d.AnioRachMax, 1)
+		}
+		s.Months[m] = me
+	}
+
+	return nil
+}
+
+// Month returns the records of a calendar month.
+func (s *StationExtremes) Month(month time.Month) MonthlyExtremes {
+	if month < time.January || int(month) > len(s.Months) {
+		return MonthlyExtremes{Month: month}
+	}
+	return s.Months[month-1]
+}
+
+// Annual returns the highest and lowest records across all months.
+func (s *StationExtremes) Annual() (high, low Extreme) {
+	for _, m := range s.Months {
+		if m.Max.Value.Valid && (!high.Value.Valid || m.Max.Value.Value > high.Value.Value) {
+			high = m.Max
+		}
+		if m.Min.Value.Valid && (!low.Value.Valid || m.Min.Value.Value < low.Value.Value) {
+			low = m.Min
+		}
+	}
+	return high, low
+}
+
+// ExtremeComparison is the result of comparing an observation against the
+// records of its month
+type ExtremeComparison struct {
+	Records MonthlyExtremes
+	// AboveMax is true when the observation exceeds the monthly maximum.
+	AboveMax bool
+	// BelowMin is true when the observation is below the monthly minimum.
+	BelowMin bool
+	// FromMax and FromMin are the observation minus each record. They are
+	// invalid when the record is missing.
+	FromMax Float
+	FromMin Float
+}
+
+// Compare compares an observation taken at t against the records for the month of t.
+// The value must be in the units of the extremes: ºC, mm or m/s.
+func (s *StationExtremes) Compare(t time.Time, value float64) ExtremeComparison {
+	c := ExtremeComparison{Records: s.Month(t.Month())}
+	if c.Records.Max.Value.Valid {
+		c.FromMax = NewFloat(value - c.Records.Max.Value.Value)
+		c.AboveMax = c.FromMax.Value > 0
+	}
+	if c.Records.Min.Value.Valid {
+		c.FromMin = NewFloat(value - c.Records.Min.Value.Value)
+		c.BelowMin = c.FromMin.Value < 0
+	}
+	return c
+}
+
+// GetExtremes retrieves the climatological extreme values of a parameter for a station,
+// identified by its AEMET station ID (indicativo).
+func (c *Client) GetExtremes(param ExtremeParameter, station string) (*StationExtremes, error) {
+	var e []*StationExtremes
+	status, err := c.getRedirWithRetry(fmt.Sprintf("api/valores/climatologicos/valoresextremos/parametro/%s/estacion/%s", string(param), station), &e)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	if len(e) == 0 {
+		return nil, fmt.Errorf("no extreme values found for station %s", station)
+	}
+
+	e[0].Parameter = param
+	e[0].Cache = status
+	return e[0], nil
+}
@@ -0,0 +1,125 @@
+package aemet
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+// loadExtremes decodes a valoresextremos payload from testdata. The files
+// follow the layout of the endpoint, thirteen entries per array with the
+// annual value last, but were written by hand.
+func loadExtremes(t *testing.T, name string) *StationExtremes {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e []*StationExtremes
+	if err := json.Unmarshal(b, &e); err != nil {
+		t.Fatal(err)
+	}
+	return e[0]
+}
+
+func TestStationExtremesTemperature(t *testing.T) {
+	e := loadExtremes(t, "valoresextremos_T_3195.json")
+
+	if e.Station != "3195" || e.Name != "MADRID, RETIRO" || e.Parameter != ExtremeTemperature {
+		t.Errorf("station = %s %q %s", e.Station, e.Name, e.Parameter)
+	}
+	if len(e.Months) != 12 {
+		t.Fatalf("%d months, want 12", len(e.Months))
+	}
+
+	jan := e.Month(time.January)
+	if jan.Max.Value != NewFloat(20.8) || !jan.Max.Date.Equal(time.Date(2021, 1, 11, 0, 0, 0, 0, madridLocation())) {
+		t.Errorf("January max = %+v", jan.Max)
+	}
+	if jan.Min.Value != NewFloat(-9.1) || !jan.Min.Date.Equal(time.Date(1945, 1, 16, 0, 0, 0, 0, madridLocation())) {
+		t.Errorf("January min = %+v", jan.Min)
+	}
+	if m := e.Month(time.Month(13)); m.Max.Value.Valid {
+		t.Errorf("month 13 = %+v, want no records", m)
+	}
+
+	// The annual entry of the payload is not a month
+	high, low := e.Annual()
+	if high.Value != NewFloat(40.6) || high.Date.Month() != time.July {
+		t.Errorf("annual high = %+v, want 40.6 in July", high)
+	}
+	if low.Value != NewFloat(-9.1) || low.Date.Month() != time.January {
+		t.Errorf("annual low = %+v, want -9.1 in January", low)
+	}
+}
+
+func TestStationExtremesPrecipitation(t *testing.T) {
+	e := loadExtremes(t, "valoresextremos_P_3195.json")
+
+	if e.Parameter != ExtremePrecipitation {
+		t.Fatalf("parameter = %s, want P", e.Parameter)
+	}
+	if sep := e.Month(time.September).Max; sep.Value != NewFloat(87.6) {
+		t.Errorf("September max = %+v, want 87.6", sep)
+	}
+	if dec := e.Month(time.December); dec.Max.Value.Valid || !dec.Max.Date.IsZero() {
+		t.Errorf("December = %+v, want no record", dec)
+	}
+	if jan := e.Month(time.January); jan.Min.Value.Valid {
+		t.Errorf("January min = %+v, want none for precipitation", jan.Min)
+	}
+}
+
+func TestStationExtremesCompare(t *testing.T) {
+	temp := loadExtremes(t, "valoresextremos_T_3195.json")
+	prec := loadExtremes(t, "valoresextremos_P_3195.json")
+	aug := time.Date(2025, 8, 12, 16, 0, 0, 0, madridLocation())
+
+	tests := []struct {
+		name     string
+		e        *StationExtremes
+		t        time.Time
+		value    float64
+		aboveMax bool
+		belowMin bool
+		fromMax  Float
+		fromMin  Float
+	}{
+		{"record high", temp, aug, 41.5, true, false, NewFloat(0.9), NewFloat(33.5)},
+		{"equal to record", temp, aug, 40.6, false, false, NewFloat(0), NewFloat(32.6)},
+		{"record low", temp, time.Date(2025, 1, 9, 7, 0, 0, 0, madridLocation()), -10, false, true, NewFloat(-30.8), NewFloat(-0.9)},
+		{"no minimum", prec, aug, 12, false, false, NewFloat(-28.1), Float{}},
+		{"no record", prec, time.Date(2025, 12, 1, 0, 0, 0, 0, madridLocation()), 30, false, false, Float{}, Float{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.e.Compare(tt.t, tt.value)
+			if c.AboveMax != tt.aboveMax || c.BelowMin != tt.belowMin {
+				t.Errorf("above max %v, below min %v; want %v, %v", c.AboveMax, c.BelowMin, tt.aboveMax, tt.belowMin)
+			}
+			if !floatNear(c.FromMax, tt.fromMax) || !floatNear(c.FromMin, tt.fromMin) {
+				t.Errorf("from max %+v, from min %+v; want %+v, %+v", c.FromMax, c.FromMin, tt.fromMax, tt.fromMin)
+			}
+			if c.Records.Month != tt.t.Month() {
+				t.Errorf("records for %s, want %s", c.Records.Month, tt.t.Month())
+			}
+		})
+	}
+}
+
+func floatNear(a, b Float) bool {
+	d := a.Value - b.Value
+	return a.Valid == b.Valid && d < 1e-9 && d > -1e-9
+}
+
+func TestExtremesPath(t *testing.T) {
+	got := requestedPath(t, `[{"indicativo": "3195"}]`, func(c *Client) error {
+		_, err := c.GetExtremes(ExtremeTemperature, "3195")
+		return err
+	})
+	if want := "api/valores/climatologicos/valoresextremos/parametro/T/estacion/3195"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+}
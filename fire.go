@@ -0,0 +1,80 @@
+package aemet
+
+import (
+	"fmt"
+	"time"
+)
+
+// FireRiskArea is an AEMET fire risk map area code
+type FireRiskArea string
+
+const (
+	FireRiskPeninsula    FireRiskArea = "p"
+	FireRiskIllesBalears FireRiskArea = "b"
+	FireRiskCanarias     FireRiskArea = "c"
+)
+
+// String returns the name of the fire risk area
+func (a FireRiskArea) String() string {
+	switch a {
+	case FireRiskPeninsula:
+		return "Península"
+	case FireRiskIllesBalears:
+		return "Illes Balears"
+	case FireRiskCanarias:
+		return "Canarias"
+	default:
+		return string(a)
+	}
+}
+
+// FireRiskKind tells forecast fire risk maps from estimated ones
+type FireRiskKind string
+
+const (
+	// FireRiskForecast maps show the forecast meteorological fire danger for a coming day.
+	FireRiskForecast FireRiskKind = "previsto"
+	// FireRiskEstimated maps show the fire danger estimated from observed conditions.
+	FireRiskEstimated FireRiskKind = "estimado"
+)
+
+// FireRiskMap represents a fire risk map image
+type FireRiskMap struct {
+	Image
+	Kind FireRiskKind
+	Area FireRiskArea
+	// Date is the day the map is valid for, in Europe/Madrid.
+	Date time.Time
+}
+
+// retrievedDay returns the date in Europe/Madrid the data was retrieved on,
+// plus the given number of days. Cached data was retrieved status.Age ago.
+func retrievedDay(status CacheStatus, days int) time.Time {
+	at := time.Now().Add(-status.Age).In(madridLocation())
+	return time.Date(at.Year(), at.Month(), at.Day()+days, 0, 0, 0, 0, at.Location())
+}
+
+// GetFireRiskForecast retrieves the forecast fire risk map for an area,
+// where day is the number of days ahead, starting at 1 for tomorrow.
+func (c *Client) GetFireRiskForecast(area FireRiskArea, day int) (*FireRiskMap, error) {
+	if day < 1 {
+		return nil, fmt.Errorf("invalid fire risk forecast day: %d", day)
+	}
+
+	img, err := c.getRedirImageWithRetry(fmt.Sprintf("api/incendios/mapasriesgo/previsto/dia/%d/area/%s", day, string(area)))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	return &FireRiskMap{Image: *img, Kind: FireRiskForecast, Area: area, Date: retrievedDay(img.Cache, day)}, nil
+}
+
+// GetFireRiskEstimated retrieves the estimated fire risk map for an area, valid for today.
+func (c *Client) GetFireRiskEstimated(area FireRiskArea) (*FireRiskMap, error) {
+	img, err := c.getRedirImageWithRetry(fmt.Sprintf("api/incendios/mapasriesgo/estimado/area/%s", string(area)))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	return &FireRiskMap{Image: *img, Kind: FireRiskEstimated, Area: area, Date: retrievedDay(img.Cache, 0)}, nil
+}
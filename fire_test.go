@@ -0,0 +1,23 @@
+package aemet
+
+import "testing"
+
+func TestFireRiskPaths(t *testing.T) {
+	for _, c := range []struct {
+		call func(c *Client) error
+		want string
+	}{
+		{func(c *Client) error {
+			_, err := c.GetFireRiskForecast(FireRiskCanarias, 2)
+			return err
+		}, "api/incendios/mapasriesgo/previsto/dia/2/area/c"},
+		{func(c *Client) error {
+			_, err := c.GetFireRiskEstimated(FireRiskPeninsula)
+			return err
+		}, "api/incendios/mapasriesgo/estimado/area/p"},
+	} {
+		if got := requestedPath(t, "GIF89a", c.call); got != c.want {
+			t.Errorf("path = %q, want %q", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package aemet
+
+import (
+	"slices"
+	"time"
+)
+
+// PeriodForecast is a normalized view of a Dia for a single period.
+// Values AEMET did not provide for the period are left missing (Valid false
+// or empty strings).
+type PeriodForecast struct {
+	// Period is never zero: entries AEMET sent without a period are
+	// reported as the whole day, 00-24.
+	Period Period
+
+	RainProb  Int
+	StormProb Int
+	SnowLevel Int
+
+	SkyCode string
+	SkyDesc string
+
+	WindDir   string
+	WindSpeed Int
+	Gust      Int
+}
+
+// wholeDay is the period used for entries AEMET sends without one.
+var wholeDay = Period{Start: 0, End: 24}
+
+func normalizePeriod(p Period) Period {
+	if p.IsZero() {
+		return wholeDay
+	}
+	return p
+}
+
+// merge fills the values missing in f with the ones in o.
+func (f *PeriodForecast) merge(o PeriodForecast) {
+	if !f.RainProb.Valid {
+		f.RainProb = o.RainProb
+	}
+	if !f.StormProb.Valid {
+		f.StormProb = o.StormProb
+	}
+	if !f.SnowLevel.Valid {
+		f.SnowLevel = o.SnowLevel
+	}
+	if f.SkyCode == "" && f.SkyDesc == "" {
+		f.SkyCode = o.SkyCode
+		f.SkyDesc = o.SkyDesc
+	}
+	if f.WindDir == "" {
+		f.WindDir = o.WindDir
+		f.WindSpeed = o.WindSpeed
+	}
+	if !f.Gust.Valid {
+		f.Gust = o.Gust
+	}
+}
+
+// Periods returns one PeriodForecast per distinct period in the day, in the
+// order AEMET lists them. Entries without a period are merged into 00-24.
+func (d *Dia) Periods() []PeriodForecast {
+	var periods []PeriodForecast
+	index := make(map[Period]int)
+
+	get := func(p Period) *PeriodForecast {
+		p = normalizePeriod(p)
+		i, ok := index[p]
+		if !ok {
+			i = len(periods)
+			index[p] = i
+			periods = append(periods, PeriodForecast{Period: p})
+		}
+		return &periods[i]
+	}
+
+	for _, v := range d.ProbPrecipitacion {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{RainProb: v.Value})
+	}
+	for _, v := range d.ProbTormenta {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{StormProb: v.Value})
+	}
+	for _, v := range d.CotaNieveProv {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{SnowLevel: v.Value})
+	}
+	for _, v := range d.EstadoCielo {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{SkyCode: v.Value, SkyDesc: v.Descripcion})
+	}
+	for _, v := range d.Viento {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{WindDir: v.Direccion, WindSpeed: v.Velocidad})
+	}
+	for _, v := range d.RachaMax {
+		f := get(v.Periodo)
+		f.merge(PeriodForecast{Gust: v.Value})
+	}
+
+	return periods
+}
+
+// Period returns the forecast for exactly the given period.
+// The zero Period is treated as the whole day.
+func (d *Dia) Period(p Period) (PeriodForecast, bool) {
+	p = normalizePeriod(p)
+	for _, f := range d.Periods() {
+		if f.Period == p {
+			return f, true
+		}
+	}
+	return PeriodForecast{}, false
+}
+
+// At returns the forecast for the given hour of the day (0-23).
+// The narrowest period containing the hour is used, and values it lacks are
+// filled in from broader periods. It reports false if no period covers the hour.
+func (d *Dia) At(hour int) (PeriodForecast, bool) {
+	var matches []PeriodForecast
+	for _, f := range d.Periods() {
+		if hour >= f.Period.Start && hour < f.Period.End {
+			matches = append(matches, f)
+		}
+	}
+	if len(matches) == 0 {
+		return PeriodForecast{}, false
+	}
+
+	slices.SortStableFunc(matches, func(a, b PeriodForecast) int {
+		return (a.Period.End - a.Period.Start) - (b.Period.End - b.Period.Start)
+	})
+
+	f := matches[0]
+	for _, o := range matches[1:] {
+		f.merge(o)
+	}
+	return f, true
+}
+
+// Day returns the forecast for the calendar day of date, evaluated in the
+// municipality's time zone. It reports false if the day is not in the forecast.
+func (m *Municipality) Day(date time.Time) (*Dia, bool) {
+	for i := range m.Prediccion.Dia {
+		d := &m.Prediccion.Dia[i]
+		y, mo, dd := date.In(d.Fecha.Location()).Date()
+		fy, fmo, fdd := d.Fecha.Date()
+		if y == fy && mo == fmo && dd == fdd {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// Today returns the forecast for the current day in the municipality's time zone.
+func (m *Municipality) Today() (*Dia, bool) {
+	return m.Day(time.Now())
+}
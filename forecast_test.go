@@ -0,0 +1,142 @@
+package aemet
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPeriodsOrder(t *testing.T) {
+	dia := loadForecastFixture(t)[0].Prediccion.Dia[0]
+
+	want := []Period{
+		{0, 24}, {0, 12}, {12, 24}, {0, 6}, {6, 12}, {12, 18}, {18, 24},
+	}
+	periods := dia.Periods()
+	if len(periods) != len(want) {
+		t.Fatalf("got %d periods, want %d", len(periods), len(want))
+	}
+	for i, p := range periods {
+		if p.Period != want[i] {
+			t.Errorf("period %d = %s, want %s", i, p.Period, want[i])
+		}
+	}
+
+	f := periods[3]
+	if f.RainProb != NewInt(35) || f.SkyCode != "11n" || f.WindDir != "S" || f.WindSpeed != NewInt(20) {
+		t.Errorf("00-06 = %+v", f)
+	}
+}
+
+func TestPeriodsWithoutPeriod(t *testing.T) {
+	// From the fifth day on, AEMET sends a single entry per value with no period
+	dia := loadForecastFixture(t)[0].Prediccion.Dia[4]
+
+	periods := dia.Periods()
+	if len(periods) != 1 {
+		t.Fatalf("got %d periods, want 1: %+v", len(periods), periods)
+	}
+
+	want := PeriodForecast{
+		Period:    wholeDay,
+		RainProb:  NewInt(20),
+		SnowLevel: NewInt(2400),
+		SkyCode:   "12",
+		SkyDesc:   "Poco nuboso",
+		WindDir:   "C",
+		WindSpeed: NewInt(5),
+		Gust:      NewInt(40),
+	}
+	if periods[0] != want {
+		t.Errorf("got %+v, want %+v", periods[0], want)
+	}
+
+	f, ok := dia.Period(Period{})
+	if !ok || f != want {
+		t.Errorf("Period(zero) = %+v, %v, want %+v", f, ok, want)
+	}
+}
+
+func TestAt(t *testing.T) {
+	dia := loadForecastFixture(t)[0].Prediccion.Dia[1]
+
+	f, ok := dia.At(15)
+	if !ok {
+		t.Fatal("no forecast at 15h")
+	}
+
+	want := PeriodForecast{
+		Period:    Period{12, 18},
+		RainProb:  NewInt(0),
+		StormProb: NewInt(10),
+		// Missing in 12-18, taken from 12-24
+		SnowLevel: NewInt(2600),
+		SkyCode:   "12n",
+		SkyDesc:   "Poco nuboso noche",
+		WindDir:   "N",
+		WindSpeed: NewInt(15),
+		// Missing in 12-18 and 12-24, taken from 00-24
+		Gust: NewInt(40),
+	}
+	if f != want {
+		t.Errorf("At(15) = %+v, want %+v", f, want)
+	}
+
+	// Days split in 12 hour periods only use those and the whole day
+	f, ok = loadForecastFixture(t)[0].Prediccion.Dia[2].At(15)
+	if !ok || f.Period != (Period{12, 24}) || f.RainProb != NewInt(35) {
+		t.Errorf("At(15) on day 3 = %+v, %v", f, ok)
+	}
+
+	if _, ok := dia.At(24); ok {
+		t.Error("At(24) reported a forecast")
+	}
+}
+
+func TestDayCanaryIslands(t *testing.T) {
+	raw, err := os.ReadFile("testdata/prediccion_diaria_28079.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Las Palmas de Gran Canaria, one hour behind the peninsula
+	raw = bytes.Replace(raw, []byte(`"id" : 28079`), []byte(`"id" : 35016`), 1)
+
+	var m []*Municipality
+	if err := json.Unmarshal(raw, &m); err != nil {
+		t.Fatal(err)
+	}
+	canary := m[0]
+
+	if name := canary.Prediccion.Dia[0].Fecha.Location().String(); name != "Atlantic/Canary" {
+		t.Fatalf("Fecha location = %s, want Atlantic/Canary", name)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+	}{
+		// 00:30 in Madrid is still 23:30 of the previous day in the islands
+		{"madrid after midnight", time.Date(2025, 5, 21, 0, 30, 0, 0, madridLocation()), "2025-05-20"},
+		{"canary before midnight", time.Date(2025, 5, 20, 23, 59, 0, 0, canaryLocation()), "2025-05-20"},
+		{"canary after midnight", time.Date(2025, 5, 21, 0, 0, 0, 0, canaryLocation()), "2025-05-21"},
+		{"utc", time.Date(2025, 5, 20, 23, 30, 0, 0, time.UTC), "2025-05-21"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := canary.Day(tt.date)
+			if !ok {
+				t.Fatal("day not found")
+			}
+			if got := d.Fecha.Format("2006-01-02"); got != tt.want {
+				t.Errorf("Day = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := canary.Day(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)); ok {
+		t.Error("Day found a date outside the forecast")
+	}
+}
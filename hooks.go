@@ -0,0 +1,174 @@
+package aemet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Leg identifies a request of the two-step AEMET fetch
+type Leg int
+
+const (
+	// LegMetadata is the request to the API endpoint, answered with the datos URL.
+	LegMetadata Leg = iota
+	// LegData is the request to the datos URL, answered with the payload.
+	LegData
+)
+
+// String returns the name of the leg
+func (l Leg) String() string {
+	switch l {
+	case LegMetadata:
+		return "metadata"
+	case LegData:
+		return "datos"
+	default:
+		return "unknown"
+	}
+}
+
+// CallInfo describes a logical call, such as GetForecastFor, spanning
+// every request and retry needed to complete it
+type CallInfo struct {
+	// Path is the API path, without host or api_key.
+	Path string
+	// Cache tells whether the call was served from the cache. It is only set in AfterCall.
+	Cache CacheStatus
+}
+
+// RequestInfo describes a single HTTP request of a call
+type RequestInfo struct {
+	Path string
+	Leg  Leg
+	// Attempt is the call attempt the request belongs to, starting at 1.
+	Attempt int
+	// Elapsed is the time taken by the request. It is only set in AfterResponse.
+	Elapsed time.Duration
+}
+
+// RetryInfo describes a retry of a call
+type RetryInfo struct {
+	Path string
+	// Attempt is the attempt about to be made, starting at 2.
+	Attempt int
+	Backoff time.Duration
+	// Err is the error of the previous attempt.
+	Err error
+}
+
+// Hook lets callers observe and adjust the client's request lifecycle.
+// Every field is optional.
+type Hook struct {
+	// BeforeCall is invoked when a call starts. The returned context is used
+	// for the rest of the call; return ctx if there is nothing to add. A nil
+	// context keeps ctx.
+	BeforeCall func(ctx context.Context, call CallInfo) context.Context
+
+	// AfterCall is invoked when a call ends, with its final error.
+	AfterCall func(ctx context.Context, call CallInfo, err error)
+
+	// BeforeRequest is invoked before each request of both legs. It may add
+	// headers or return a request with a new context; the returned request is sent.
+	// Returning nil keeps req. The request URL carries the api_key query parameter.
+	BeforeRequest func(req *http.Request, info RequestInfo) *http.Request
+
+	// AfterResponse is invoked after each request of both legs, with the request
+	// returned by BeforeRequest. resp is nil when err is set. The hook may replace
+	// resp.Body, e.g. to record it, as long as the replacement yields the same bytes.
+	AfterResponse func(req *http.Request, resp *http.Response, info RequestInfo, err error)
+
+	// OnRetry is invoked before waiting to retry a failed attempt.
+	OnRetry func(ctx context.Context, info RetryInfo)
+}
+
+type attemptKey struct{}
+
+// withAttempt records the attempt number in ctx
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func attemptFrom(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+func (c *Client) beforeCall(ctx context.Context, call CallInfo) context.Context {
+	for _, h := range c.config.Hooks {
+		if h.BeforeCall != nil {
+			if next := h.BeforeCall(ctx, call); next != nil {
+				ctx = next
+			}
+		}
+	}
+	return ctx
+}
+
+func (c *Client) afterCall(ctx context.Context, call CallInfo, err error) {
+	for _, h := range c.config.Hooks {
+		if h.AfterCall != nil {
+			h.AfterCall(ctx, call, err)
+		}
+	}
+}
+
+func (c *Client) onRetry(ctx context.Context, info RetryInfo) {
+	for _, h := range c.config.Hooks {
+		if h.OnRetry != nil {
+			h.OnRetry(ctx, info)
+		}
+	}
+}
+
+// do sends a GET request for one leg of a call, running the request hooks around it.
+// Requests wait for the rate limiter before the hooks run.
+func (c *Client) do(ctx context.Context, path string, leg Leg, rawURL string) (*http.Response, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info := RequestInfo{Path: path, Leg: leg, Attempt: attemptFrom(ctx)}
+	for _, h := range c.config.Hooks {
+		if h.BeforeRequest != nil {
+			if next := h.BeforeRequest(req, info); next != nil {
+				req = next
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	info.Elapsed = time.Since(start)
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// Keep the API key out of errors and logs
+		urlErr.URL = redactAPIKey(urlErr.URL)
+	}
+
+	if err != nil {
+		c.logger.DebugContext(ctx, "request failed",
+			"path", path, "leg", leg.String(), "attempt", info.Attempt, "elapsed", info.Elapsed, "error", err)
+	} else {
+		c.logger.DebugContext(ctx, "request",
+			"path", path, "leg", leg.String(), "attempt", info.Attempt, "elapsed", info.Elapsed, "status", resp.StatusCode)
+	}
+
+	for _, h := range c.config.Hooks {
+		if h.AfterResponse != nil {
+			h.AfterResponse(req, resp, info, err)
+		}
+	}
+
+	return resp, err
+}
@@ -0,0 +1,123 @@
+package aemet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+type ctxKey string
+
+func TestHookOrder(t *testing.T) {
+	server := forecastServer(t)
+	var events []string
+	var metadataCalls int
+
+	hook := func(name string) Hook {
+		return Hook{
+			BeforeCall: func(ctx context.Context, call CallInfo) context.Context {
+				events = append(events, fmt.Sprintf("%s BeforeCall %s", name, call.Path))
+				return context.WithValue(ctx, ctxKey(name), true)
+			},
+			AfterCall: func(ctx context.Context, call CallInfo, err error) {
+				// Contexts returned by BeforeCall reach the end of the call
+				events = append(events, fmt.Sprintf("%s AfterCall %v %v", name, ctx.Value(ctxKey(name)), err))
+			},
+			BeforeRequest: func(req *http.Request, info RequestInfo) *http.Request {
+				events = append(events, fmt.Sprintf("%s BeforeRequest %s %d %v", name, info.Leg, info.Attempt, req.Context().Value(ctxKey(name))))
+				return req
+			},
+			AfterResponse: func(req *http.Request, resp *http.Response, info RequestInfo, err error) {
+				events = append(events, fmt.Sprintf("%s AfterResponse %s %d %d", name, info.Leg, info.Attempt, resp.StatusCode))
+			},
+			OnRetry: func(ctx context.Context, info RetryInfo) {
+				events = append(events, fmt.Sprintf("%s OnRetry %d", name, info.Attempt))
+			},
+		}
+	}
+
+	client, err := New(Config{
+		AemetApiKey: "test",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/opendata/sh/forecast" {
+				metadataCalls++
+				if metadataCalls == 1 {
+					return jsonResponse(http.StatusInternalServerError, `{"descripcion": "Error interno", "estado": 500}`), nil
+				}
+			}
+			return server(req)
+		})},
+		Hooks: []Hook{hook("a"), hook("b")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetForecastFor("28079"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"a BeforeCall api/prediccion/especifica/municipio/diaria/28079",
+		"b BeforeCall api/prediccion/especifica/municipio/diaria/28079",
+		"a BeforeRequest metadata 1 true",
+		"b BeforeRequest metadata 1 true",
+		"a AfterResponse metadata 1 500",
+		"b AfterResponse metadata 1 500",
+		"a OnRetry 2",
+		"b OnRetry 2",
+		"a BeforeRequest metadata 2 true",
+		"b BeforeRequest metadata 2 true",
+		"a AfterResponse metadata 2 200",
+		"b AfterResponse metadata 2 200",
+		"a BeforeRequest datos 2 true",
+		"b BeforeRequest datos 2 true",
+		"a AfterResponse datos 2 200",
+		"b AfterResponse datos 2 200",
+		"a AfterCall true <nil>",
+		"b AfterCall true <nil>",
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events:\n%q\nwant:\n%q", events, want)
+	}
+}
+
+func TestBeforeRequestReplace(t *testing.T) {
+	server := forecastServer(t)
+	var seen []string
+
+	client, err := New(Config{
+		AemetApiKey: "test",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.Header.Get("X-Trace")+" "+req.Header.Get("X-Second"))
+			return server(req)
+		})},
+		Hooks: []Hook{
+			{BeforeRequest: func(req *http.Request, info RequestInfo) *http.Request {
+				req = req.Clone(req.Context())
+				req.Header.Set("X-Trace", info.Leg.String())
+				return req
+			}},
+			// A nil request keeps the one from the previous hook
+			{BeforeRequest: func(req *http.Request, info RequestInfo) *http.Request {
+				req.Header.Set("X-Second", "yes")
+				return nil
+			}},
+			{BeforeCall: func(ctx context.Context, call CallInfo) context.Context {
+				return nil
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetForecastFor("28079"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"metadata yes", "datos yes"}; !slices.Equal(seen, want) {
+		t.Errorf("sent headers = %q, want %q", seen, want)
+	}
+}
@@ -0,0 +1,15 @@
+package aemet
+
+import "time"
+
+// Image represents an image product served by AEMET, such as maps or radar composites
+type Image struct {
+	Data        []byte
+	ContentType string
+	// ValidAt is the time AEMET last updated the image, taken from the
+	// Last-Modified header of the datos response, as the API gives no other
+	// timestamp for image products. It is the zero time when the header is missing.
+	ValidAt time.Time
+	// Cache tells whether the image was served from the client cache.
+	Cache CacheStatus
+}
@@ -0,0 +1,175 @@
+// Command beachgen converts AEMET's beach code file, Playas_codigos.csv, into
+// the beach catalogue embedded in the aemet package.
+//
+// Download the file from https://www.aemet.es/es/eltiempo/prediccion/playas
+// into the repository root and run go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// beach mirrors aemet.BeachInfo
+type beach struct {
+	ID             string `json:"id"`
+	Name           string `json:"nombre"`
+	ProvinceID     string `json:"id_provincia"`
+	Province       string `json:"provincia"`
+	MunicipalityID string `json:"id_municipio"`
+	Municipality   string `json:"municipio"`
+	Latitude       string `json:"latitud"`
+	Longitude      string `json:"longitud"`
+}
+
+// columns maps the CSV header names to the beach fields they fill
+var columns = map[string]func(b *beach) *string{
+	"ID_PLAYA":         func(b *beach) *string { return &b.ID },
+	"NOMBRE_PLAYA":     func(b *beach) *string { return &b.Name },
+	"ID_PROVINCIA":     func(b *beach) *string { return &b.ProvinceID },
+	"NOMBRE_PROVINCIA": func(b *beach) *string { return &b.Province },
+	"ID_MUNICIPIO":     func(b *beach) *string { return &b.MunicipalityID },
+	"NOMBRE_MUNICIPIO": func(b *beach) *string { return &b.Municipality },
+	"LATITUD":          func(b *beach) *string { return &b.Latitude },
+	"LONGITUD":         func(b *beach) *string { return &b.Longitude },
+}
+
+// decodeText returns b as UTF-8, converting it from ISO-8859-1 when it is not
+// valid UTF-8, and without a byte order mark
+func decodeText(b []byte) string {
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	var s strings.Builder
+	for _, c := range b {
+		s.WriteRune(rune(c))
+	}
+	return s.String()
+}
+
+// pad left-pads numeric codes with zeros to width, as spreadsheets drop them
+func pad(code string, width int) string {
+	if code == "" || strings.Trim(code, "0123456789") != "" {
+		return code
+	}
+	return strings.Repeat("0", max(width-len(code), 0)) + code
+}
+
+// parseBeaches parses the beach code file, separated by semicolons or commas
+func parseBeaches(r io.Reader) ([]beach, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := decodeText(b)
+
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+	first, _, _ := strings.Cut(text, "\n")
+	if strings.Count(first, ";") > strings.Count(first, ",") {
+		cr.Comma = ';'
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+	fields := make([]func(b *beach) *string, len(header))
+	found := 0
+	for i, name := range header {
+		if field, ok := columns[strings.ToUpper(strings.TrimSpace(name))]; ok {
+			fields[i] = field
+			found++
+		}
+	}
+	if found != len(columns) {
+		return nil, fmt.Errorf("unexpected header: %q", header)
+	}
+
+	var beaches []beach
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var b beach
+		for i, value := range record {
+			if i < len(fields) && fields[i] != nil {
+				*fields[i](&b) = strings.TrimSpace(value)
+			}
+		}
+		if b.ID == "" {
+			continue
+		}
+		b.ID = pad(b.ID, 7)
+		b.ProvinceID = pad(b.ProvinceID, 2)
+		b.MunicipalityID = pad(b.MunicipalityID, 5)
+		beaches = append(beaches, b)
+	}
+
+	return beaches, nil
+}
+
+// generate writes the Go source declaring beachesJSON
+func generate(w io.Writer, beaches []beach) error {
+	data, err := json.MarshalIndent(beaches, "", "  ")
+	if err != nil {
+		return err
+	}
+	if bytes.ContainsRune(data, '`') {
+		return fmt.Errorf("beach data contains a backquote")
+	}
+
+	_, err = fmt.Fprintf(w, `// Code generated by beachgen from Playas_codigos.csv; DO NOT EDIT.
+
+package aemet
+
+// beachesJSON holds the AEMET beach catalogue
+const beachesJSON = %s
+`, "`"+string(data)+"`")
+	return err
+}
+
+func main() {
+	out := flag.String("o", "beaches_data.go", "output file")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: beachgen [-o file] Playas_codigos.csv")
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	beaches, err := parseBeaches(f)
+	if err != nil {
+		log.Fatalf("error parsing %s: %v", flag.Arg(0), err)
+	}
+	if len(beaches) == 0 {
+		log.Fatalf("no beaches found in %s", flag.Arg(0))
+	}
+
+	var src bytes.Buffer
+	if err := generate(&src, beaches); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+)
+
+// sample follows the header of Playas_codigos.csv; the rows are made up, not
+// copied from the AEMET file, and encoded in ISO-8859-1 like it.
+const sample = "ID_PLAYA;NOMBRE_PLAYA;ID_PROVINCIA;NOMBRE_PROVINCIA;ID_MUNICIPIO;NOMBRE_MUNICIPIO;LATITUD;LONGITUD\r\n" +
+	"2006901;La Concha;20;Gipuzkoa;20069;Donostia/San Sebasti\xe1n;43 19 04;-1 59 11\r\n" +
+	"301401;Postiguet;3;Alacant/Alicante;3014;Alicante/Alacant;38 20 46;-0 28 37\r\n" +
+	";;;;;;;\r\n"
+
+func TestParseBeaches(t *testing.T) {
+	beaches, err := parseBeaches(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beaches) != 2 {
+		t.Fatalf("parsed %d beaches, want 2", len(beaches))
+	}
+
+	want := beach{
+		ID: "2006901", Name: "La Concha",
+		ProvinceID: "20", Province: "Gipuzkoa",
+		MunicipalityID: "20069", Municipality: "Donostia/San Sebastián",
+		Latitude: "43 19 04", Longitude: "-1 59 11",
+	}
+	if beaches[0] != want {
+		t.Errorf("beach = %+v, want %+v", beaches[0], want)
+	}
+
+	// Codes missing their leading zeros are padded
+	if b := beaches[1]; b.ID != "0301401" || b.ProvinceID != "03" || b.MunicipalityID != "03014" {
+		t.Errorf("codes = %q, %q, %q, want 0301401, 03, 03014", b.ID, b.ProvinceID, b.MunicipalityID)
+	}
+}
+
+func TestParseBeachesHeader(t *testing.T) {
+	if _, err := parseBeaches(strings.NewReader("playa,nombre\n1,La Concha\n")); err == nil {
+		t.Error("expected an error for an unknown header")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	beaches, err := parseBeaches(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var src bytes.Buffer
+	if err := generate(&src, beaches); err != nil {
+		t.Fatal(err)
+	}
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(formatted, src.Bytes()) {
+		t.Error("generated source is not gofmt-formatted")
+	}
+	if !strings.Contains(src.String(), `"id_municipio": "20069"`) {
+		t.Errorf("generated source lacks the beach data:\n%s", src.String())
+	}
+}
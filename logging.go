@@ -0,0 +1,57 @@
+package aemet
+
+import (
+	"log"
+	"log/slog"
+	"net/url"
+)
+
+// newLogger returns the structured logger for config: SlogLogger if set, an
+// adapter writing to the legacy Logger otherwise, or a logger that discards
+// everything.
+func newLogger(config Config) *slog.Logger {
+	switch {
+	case config.SlogLogger != nil:
+		return config.SlogLogger
+	case config.Logger != nil:
+		return slog.New(slog.NewTextHandler(logWriter{config.Logger}, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				// log.Logger adds its own timestamp
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+	default:
+		return slog.New(slog.DiscardHandler)
+	}
+}
+
+// logWriter writes each log line through a log.Logger, so its prefix and flags apply
+type logWriter struct {
+	logger *log.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	if err := w.logger.Output(2, string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// redactAPIKey replaces the api_key query parameter of rawURL, if any.
+func redactAPIKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	if !q.Has("api_key") {
+		return rawURL
+	}
+	q.Set("api_key", "REDACTED")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,260 @@
+package aemet
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Coast is an AEMET coastal waters forecast code
+type Coast string
+
+const (
+	CoastCantabrico          Coast = "40"
+	CoastGalicia             Coast = "41"
+	CoastAndaluciaOccidental Coast = "42"
+	CoastAndaluciaOriental   Coast = "43"
+	CoastMurcia              Coast = "44"
+	CoastValenciana          Coast = "45"
+	CoastCataluna            Coast = "46"
+	CoastIllesBalears        Coast = "47"
+	CoastCanarias            Coast = "48"
+)
+
+var coastNames = map[Coast]string{
+	CoastCantabrico:          "Asturias, Cantabria y País Vasco",
+	CoastGalicia:             "Galicia",
+	CoastAndaluciaOccidental: "Andalucía occidental y Ceuta",
+	CoastAndaluciaOriental:   "Andalucía oriental y Melilla",
+	CoastMurcia:              "Murcia",
+	CoastValenciana:          "Comunitat Valenciana",
+	CoastCataluna:            "Cataluña",
+	CoastIllesBalears:        "Illes Balears",
+	CoastCanarias:            "Canarias",
+}
+
+// Coasts lists every coastal waters forecast code
+var Coasts = []Coast{
+	CoastCantabrico, CoastGalicia, CoastAndaluciaOccidental, CoastAndaluciaOriental,
+	CoastMurcia, CoastValenciana, CoastCataluna, CoastIllesBalears, CoastCanarias,
+}
+
+// String returns the name of the coast
+func (c Coast) String() string {
+	if name, ok := coastNames[c]; ok {
+		return name
+	}
+	return string(c)
+}
+
+// HighSeasArea is an AEMET high seas forecast area code
+type HighSeasArea string
+
+const (
+	HighSeasAtlantic      HighSeasArea = "0"
+	HighSeasMediterranean HighSeasArea = "1"
+	HighSeasCanarias      HighSeasArea = "2"
+)
+
+// String returns the name of the high seas area
+func (a HighSeasArea) String() string {
+	switch a {
+	case HighSeasAtlantic:
+		return "Atlántico"
+	case HighSeasMediterranean:
+		return "Mediterráneo"
+	case HighSeasCanarias:
+		return "Canarias"
+	default:
+		return string(a)
+	}
+}
+
+// TextoMaritimo represents a free-text block of a maritime forecast
+type TextoMaritimo struct {
+	ID     Code   `json:"id"`
+	Nombre string `json:"nombre"`
+	Inicio string `json:"inicio"`
+	Fin    string `json:"fin"`
+	Texto  string `json:"texto"`
+}
+
+// ZonaMaritima represents a maritime forecast zone, optionally split into subzones
+type ZonaMaritima struct {
+	ID      Code           `json:"id"`
+	Nombre  string         `json:"nombre"`
+	Texto   string         `json:"texto"`
+	Subzona []ZonaMaritima `json:"subzona"`
+}
+
+// PrediccionMaritima represents the per-zone forecast
+type PrediccionMaritima struct {
+	Inicio string         `json:"inicio"`
+	Fin    string         `json:"fin"`
+	Zona   []ZonaMaritima `json:"zona"`
+}
+
+// MaritimeForecast represents a coastal or high seas forecast
+type MaritimeForecast struct {
+	Origen     Origen             `json:"origen"`
+	Aviso      TextoMaritimo      `json:"aviso"`
+	Situacion  TextoMaritimo      `json:"situacion"`
+	Prediccion PrediccionMaritima `json:"prediccion"`
+	Tendencia  TextoMaritimo      `json:"tendencia"`
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus `json:"-"`
+}
+
+// Zones returns every zone with forecast text, flattening subzones.
+func (f *MaritimeForecast) Zones() []ZonaMaritima {
+	var zones []ZonaMaritima
+	var walk func([]ZonaMaritima)
+	walk = func(zs []ZonaMaritima) {
+		for _, z := range zs {
+			if z.Texto != "" {
+				zones = append(zones, z)
+			}
+			walk(z.Subzona)
+		}
+	}
+	walk(f.Prediccion.Zona)
+	return zones
+}
+
+// SeaState is a level of the Douglas sea scale, as named by AEMET
+type SeaState int
+
+const (
+	SeaCalm SeaState = iota
+	SeaRippled
+	SeaSmooth
+	SeaSlight
+	SeaModerate
+	SeaRough
+	SeaVeryRough
+	SeaHigh
+	SeaVeryHigh
+	SeaPhenomenal
+)
+
+var seaStateNames = []string{
+	"mar llana", "mar rizada", "marejadilla", "marejada", "fuerte marejada",
+	"mar gruesa", "mar muy gruesa", "mar arbolada", "mar montañosa", "mar enorme",
+}
+
+// String returns the Spanish name AEMET uses for the sea state
+func (s SeaState) String() string {
+	if s < 0 || int(s) >= len(seaStateNames) {
+		return "desconocido"
+	}
+	return seaStateNames[s]
+}
+
+// ZoneWind represents the wind forecast for a zone, in the Beaufort scale
+type ZoneWind struct {
+	// Direction as written by AEMET, e.g. "noroeste" or "variable".
+	Direction string
+	Min       Int
+	Max       Int
+}
+
+// Swell represents the swell forecast for a zone
+type Swell struct {
+	Direction string
+	// MinHeight and MaxHeight in meters.
+	MinHeight Float
+	MaxHeight Float
+}
+
+// MaritimeConditions represents the conditions parsed from a zone forecast text
+type MaritimeConditions struct {
+	Wind []ZoneWind
+	// SeaState holds every sea state mentioned, in order of appearance.
+	SeaState []SeaState
+	Swell    []Swell
+}
+
+const directionPattern = `noroeste|nordeste|noreste|sudoeste|suroeste|sudeste|sureste|norte|sur|este|oeste|variable`
+
+var (
+	zoneWindRe = regexp.MustCompile(`(?i)\b(` + directionPattern + `)\s+(\d{1,2})(?:\s+(?:a|o)\s+(\d{1,2}))?\b`)
+	// seaStateRe matches sea states with or without the leading "mar", since
+	// AEMET writes ranges such as "rizada a marejadilla" or "gruesa a muy gruesa"
+	seaStateRe = regexp.MustCompile(`(?i)\b(fuerte marejada|marejadilla|marejada|(?:mar\s+)?(?:llana|rizada|muy gruesa|gruesa|arbolada|montañosa|enorme))\b`)
+	swellRe    = regexp.MustCompile(`(?i)mar de fondo(?:\s+(?:del?\s+)?(` + directionPattern + `))?(?:\s+(?:de\s+)?(\d+(?:[.,]\d+)?)(?:\s+(?:a|o)\s+(\d+(?:[.,]\d+)?))?\s*m(?:etros)?\b)?`)
+)
+
+// parseSeaState returns the sea state named s, as matched by seaStateRe
+func parseSeaState(s string) (SeaState, bool) {
+	s = strings.Join(strings.Fields(s), " ")
+	if !strings.HasPrefix(s, "mar ") && !strings.Contains(s, "marejad") {
+		s = "mar " + s
+	}
+	for i, name := range seaStateNames {
+		if s == name {
+			return SeaState(i), true
+		}
+	}
+	return 0, false
+}
+
+// Conditions parses wind, sea state and swell from the zone forecast text.
+func (z ZonaMaritima) Conditions() MaritimeConditions {
+	var c MaritimeConditions
+	text := strings.ToLower(z.Texto)
+
+	// Swell is parsed first and removed, so its direction is not taken for wind
+	for _, m := range swellRe.FindAllStringSubmatch(text, -1) {
+		s := Swell{Direction: m[1]}
+		s.MinHeight, _ = parseFloat(m[2])
+		s.MaxHeight, _ = parseFloat(m[3])
+		if !s.MaxHeight.Valid {
+			s.MaxHeight = s.MinHeight
+		}
+		c.Swell = append(c.Swell, s)
+	}
+	text = swellRe.ReplaceAllString(text, "")
+
+	for _, m := range zoneWindRe.FindAllStringSubmatch(text, -1) {
+		w := ZoneWind{Direction: m[1]}
+		w.Min, _ = parseInt(m[2])
+		w.Max, _ = parseInt(m[3])
+		if !w.Max.Valid {
+			w.Max = w.Min
+		}
+		c.Wind = append(c.Wind, w)
+	}
+
+	for _, m := range seaStateRe.FindAllString(text, -1) {
+		if state, ok := parseSeaState(m); ok {
+			c.SeaState = append(c.SeaState, state)
+		}
+	}
+
+	return c
+}
+
+// GetCoastalForecast retrieves the coastal waters forecast for a coast.
+func (c *Client) GetCoastalForecast(coast Coast) (*MaritimeForecast, error) {
+	return c.getMaritimeForecast(fmt.Sprintf("api/prediccion/maritima/costera/costa/%s", string(coast)))
+}
+
+// GetHighSeasForecast retrieves the high seas forecast for an area.
+func (c *Client) GetHighSeasForecast(area HighSeasArea) (*MaritimeForecast, error) {
+	return c.getMaritimeForecast(fmt.Sprintf("api/prediccion/maritima/altamar/area/%s", string(area)))
+}
+
+func (c *Client) getMaritimeForecast(path string) (*MaritimeForecast, error) {
+	var f []*MaritimeForecast
+	status, err := c.getRedirWithRetry(path, &f)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	if len(f) == 0 {
+		return nil, fmt.Errorf("no maritime forecast data found")
+	}
+
+	f[0].Cache = status
+	return f[0], nil
+}
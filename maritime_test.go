@@ -0,0 +1,94 @@
+package aemet
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestZoneConditions(t *testing.T) {
+	tests := []struct {
+		name  string
+		texto string
+		want  MaritimeConditions
+	}{
+		{
+			name:  "coastal",
+			texto: "Oeste o noroeste 3 a 4, arreciando a 5 al final. Marejadilla o marejada, aumentando a fuerte marejada. Mar de fondo del noroeste de 2 a 3 m.",
+			want: MaritimeConditions{
+				Wind:     []ZoneWind{{Direction: "noroeste", Min: NewInt(3), Max: NewInt(4)}},
+				SeaState: []SeaState{SeaSmooth, SeaSlight, SeaModerate},
+				Swell:    []Swell{{Direction: "noroeste", MinHeight: NewFloat(2), MaxHeight: NewFloat(3)}},
+			},
+		},
+		{
+			name:  "states without mar",
+			texto: "Variable 1 a 3, predominando el este. Rizada a marejadilla.",
+			want: MaritimeConditions{
+				Wind:     []ZoneWind{{Direction: "variable", Min: NewInt(1), Max: NewInt(3)}},
+				SeaState: []SeaState{SeaRippled, SeaSmooth},
+			},
+		},
+		{
+			name:  "high seas",
+			texto: "Norte 6 a 7, ocasionalmente 8 al principio. Gruesa a muy gruesa. Mar de fondo del noroeste de 4 a 5 metros.",
+			want: MaritimeConditions{
+				Wind:     []ZoneWind{{Direction: "norte", Min: NewInt(6), Max: NewInt(7)}},
+				SeaState: []SeaState{SeaRough, SeaVeryRough},
+				Swell:    []Swell{{Direction: "noroeste", MinHeight: NewFloat(4), MaxHeight: NewFloat(5)}},
+			},
+		},
+		{
+			name:  "wind change",
+			texto: "Sur 3 al principio, girando a oeste 5 o 6. Mar gruesa. Mar de fondo del oeste de 1,5 m.",
+			want: MaritimeConditions{
+				Wind: []ZoneWind{
+					{Direction: "sur", Min: NewInt(3), Max: NewInt(3)},
+					{Direction: "oeste", Min: NewInt(5), Max: NewInt(6)},
+				},
+				SeaState: []SeaState{SeaRough},
+				Swell:    []Swell{{Direction: "oeste", MinHeight: NewFloat(1.5), MaxHeight: NewFloat(1.5)}},
+			},
+		},
+		{
+			name:  "swell without height",
+			texto: "Nordeste 2. Mar rizada. Mar de fondo del norte.",
+			want: MaritimeConditions{
+				Wind:     []ZoneWind{{Direction: "nordeste", Min: NewInt(2), Max: NewInt(2)}},
+				SeaState: []SeaState{SeaRippled},
+				Swell:    []Swell{{Direction: "norte"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ZonaMaritima{Texto: tt.texto}.Conditions()
+			if !slices.Equal(got.Wind, tt.want.Wind) {
+				t.Errorf("Wind = %+v, want %+v", got.Wind, tt.want.Wind)
+			}
+			if !slices.Equal(got.SeaState, tt.want.SeaState) {
+				t.Errorf("SeaState = %v, want %v", got.SeaState, tt.want.SeaState)
+			}
+			if !slices.Equal(got.Swell, tt.want.Swell) {
+				t.Errorf("Swell = %+v, want %+v", got.Swell, tt.want.Swell)
+			}
+		})
+	}
+}
+
+func TestMaritimeForecastPaths(t *testing.T) {
+	got := requestedPath(t, `[{}]`, func(c *Client) error {
+		_, err := c.GetCoastalForecast(CoastGalicia)
+		return err
+	})
+	if want := "api/prediccion/maritima/costera/costa/41"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+
+	got = requestedPath(t, `[{}]`, func(c *Client) error {
+		_, err := c.GetHighSeasForecast(HighSeasCanarias)
+		return err
+	})
+	if want := "api/prediccion/maritima/altamar/area/2"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+}
@@ -1,9 +1,16 @@
 package aemet
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
 type WeatherStation struct {
 	Latitude  string `json:"latitud"`
 	Province  string `json:"provincia"`
-	Altitude  string `json:"altitud"`
+	Altitude  Int    `json:"altitud"`
 	ID        string `json:"indicativo"`
 	Name      string `json:"nombre"`
 	IndSinop  string `json:"indsinop"`
@@ -12,61 +19,78 @@ type WeatherStation struct {
 
 // ProbPrecipitacion represents precipitation probability data
 type ProbPrecipitacion struct {
-	Value   int    `json:"value"`
-	Periodo string `json:"periodo"`
+	Value   Int    `json:"value"`
+	Periodo Period `json:"periodo,omitzero"`
 }
 
-// CotaNieveProv represents snow level data
+// CotaNieveProv represents snow level data in meters
 type CotaNieveProv struct {
-	Value   string `json:"value"`
-	Periodo string `json:"periodo"`
+	Value   Int    `json:"value"`
+	Periodo Period `json:"periodo,omitzero"`
 }
 
 // EstadoCielo represents sky condition data
 type EstadoCielo struct {
 	Value       string `json:"value"`
-	Periodo     string `json:"periodo"`
+	Periodo     Period `json:"periodo,omitzero"`
 	Descripcion string `json:"descripcion"`
 }
 
 // Viento represents wind data
 type Viento struct {
 	Direccion string `json:"direccion"`
-	Velocidad int    `json:"velocidad"`
-	Periodo   string `json:"periodo"`
+	Velocidad Int    `json:"velocidad"`
+	Periodo   Period `json:"periodo,omitzero"`
 }
 
-// RachaMax represents maximum wind gust data
+// RachaMax represents maximum wind gust data in km/h
 type RachaMax struct {
-	Value   string `json:"value"`
-	Periodo string `json:"periodo"`
+	Value   Int    `json:"value"`
+	Periodo Period `json:"periodo,omitzero"`
 }
 
 // Dato represents hourly data points
 type Dato struct {
-	Value int `json:"value"`
+	Value Int `json:"value"`
 	Hora  int `json:"hora"`
 }
 
-// Temperatura represents temperature data
+// Temperatura represents daily extremes plus hourly data points.
+// It is also used for thermal sensation and relative humidity.
 type Temperatura struct {
-	Maxima int    `json:"maxima"`
-	Minima int    `json:"minima"`
+	Maxima Int    `json:"maxima"`
+	Minima Int    `json:"minima"`
 	Dato   []Dato `json:"dato"`
 }
 
-// Dia represents a day's forecast
+// ProbTormenta represents storm probability data
+type ProbTormenta struct {
+	Value   Int    `json:"value"`
+	Periodo Period `json:"periodo,omitzero"`
+	Dato    []Dato `json:"dato,omitempty"`
+}
+
+// Dia represents a day's forecast.
+// Per-period slices hold one entry per period AEMET forecasts for the day:
+// the first days are split into 6 and 12 hour periods, later days only carry
+// a single entry for the whole day.
 type Dia struct {
 	ProbPrecipitacion []ProbPrecipitacion `json:"probPrecipitacion"`
+	ProbTormenta      []ProbTormenta      `json:"probTormenta,omitempty"`
 	CotaNieveProv     []CotaNieveProv     `json:"cotaNieveProv"`
 	EstadoCielo       []EstadoCielo       `json:"estadoCielo"`
 	Viento            []Viento            `json:"viento"`
 	RachaMax          []RachaMax          `json:"rachaMax"`
-	Temperatura       Temperatura         `json:"temperatura"`
-	SensTermica       Temperatura         `json:"sensTermica"`
-	HumedadRelativa   Temperatura         `json:"humedadRelativa"`
-	UvMax             int                 `json:"uvMax"`
-	Fecha             string              `json:"fecha"`
+	// Temperatura holds the daily extremes in °C and, for the first days,
+	// hourly values at 06, 12, 18 and 24h.
+	Temperatura Temperatura `json:"temperatura"`
+	// SensTermica holds the thermal sensation in °C, with the same layout as Temperatura.
+	SensTermica Temperatura `json:"sensTermica"`
+	// HumedadRelativa holds the relative humidity in %, with the same layout as Temperatura.
+	HumedadRelativa Temperatura `json:"humedadRelativa"`
+	// UvMax is the maximum UV index, only available for the first days.
+	UvMax Int       `json:"uvMax,omitzero"`
+	Fecha time.Time `json:"fecha"`
 }
 
 // Prediccion represents the prediction structure
@@ -74,12 +98,94 @@ type Prediccion struct {
 	Dia []Dia `json:"dia"`
 }
 
+// Origen holds the attribution block AEMET attaches to every forecast
+type Origen struct {
+	Productor string `json:"productor"`
+	Web       string `json:"web"`
+	Enlace    string `json:"enlace"`
+	Language  string `json:"language"`
+	Copyright string `json:"copyright"`
+	NotaLegal string `json:"notaLegal"`
+}
+
 // Municipality represents a municipality forecast
 type Municipality struct {
-	Elaborado  string     `json:"elaborado"`
+	Origen     Origen     `json:"origen"`
+	Elaborado  time.Time  `json:"elaborado"`
 	Nombre     string     `json:"nombre"`
 	Provincia  string     `json:"provincia"`
 	Prediccion Prediccion `json:"prediccion"`
 	ID         int        `json:"id"`
-	Version    float64    `json:"version"`
-}
\ No newline at end of file
+	Version    Float      `json:"version"`
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The day is parsed in Europe/Madrid; Municipality re-anchors it to the
+// municipality's own time zone once its ID is known.
+func (d *Dia) UnmarshalJSON(b []byte) error {
+	type dia Dia
+	aux := struct {
+		*dia
+		Fecha string `json:"fecha"`
+	}{dia: (*dia)(d)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	fecha, err := parseAemetTime(aux.Fecha, madridLocation())
+	if err != nil {
+		return fmt.Errorf("error decoding fecha: %w", err)
+	}
+	d.Fecha = fecha
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Dia) MarshalJSON() ([]byte, error) {
+	type dia Dia
+	return json.Marshal(struct {
+		dia
+		Fecha string `json:"fecha"`
+	}{dia: dia(d), Fecha: formatAemetTime(d.Fecha)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Elaborado and every Dia.Fecha are expressed in the municipality's time zone,
+// see MunicipalityLocation.
+func (m *Municipality) UnmarshalJSON(b []byte) error {
+	type municipality Municipality
+	aux := struct {
+		*municipality
+		Elaborado string `json:"elaborado"`
+	}{municipality: (*municipality)(m)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	loc := MunicipalityLocation(strconv.Itoa(m.ID))
+	elaborado, err := parseAemetTime(aux.Elaborado, loc)
+	if err != nil {
+		return fmt.Errorf("error decoding elaborado: %w", err)
+	}
+	m.Elaborado = elaborado
+
+	for i := range m.Prediccion.Dia {
+		m.Prediccion.Dia[i].Fecha = reanchor(m.Prediccion.Dia[i].Fecha, loc)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Municipality) MarshalJSON() ([]byte, error) {
+	type municipality Municipality
+	return json.Marshal(struct {
+		municipality
+		Elaborado string `json:"elaborado"`
+	}{municipality: municipality(m), Elaborado: formatAemetTime(m.Elaborado)})
+}
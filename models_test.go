@@ -0,0 +1,241 @@
+package aemet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// loadForecastFixture decodes testdata/prediccion_diaria_28079.json, a Madrid
+// forecast written by hand in the layout of the daily endpoint. It is not a
+// recording; TestMunicipalityRoundTripReplay covers recorded forecasts.
+func loadForecastFixture(t *testing.T) []*Municipality {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/prediccion_diaria_28079.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m []*Municipality
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+	if len(m) != 1 {
+		t.Fatalf("got %d forecasts, want 1", len(m))
+	}
+	return m
+}
+
+// TestMunicipalityRoundTrip fails when a field AEMET sends is dropped by the
+// models, or comes back with a different value.
+func TestMunicipalityRoundTrip(t *testing.T) {
+	raw, err := os.ReadFile("testdata/prediccion_diaria_28079.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, raw, &[]*Municipality{})
+}
+
+// TestMunicipalityRoundTripReplay runs the round trip on the recorded daily forecast.
+func TestMunicipalityRoundTripReplay(t *testing.T) {
+	raw, _, err := replayClient(t).getRedirTextWithRetry("api/prediccion/especifica/municipio/diaria/28079")
+	skipUnrecorded(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRoundTrip(t, []byte(raw), &[]*Municipality{})
+}
+
+// TestProbTormentaReplay runs the round trip on the storm probabilities of the
+// recorded hourly forecast, whose entries may carry hourly dato values.
+func TestProbTormentaReplay(t *testing.T) {
+	raw, _, err := replayClient(t).getRedirTextWithRetry("api/prediccion/especifica/municipio/horaria/28079")
+	skipUnrecorded(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var forecasts []struct {
+		Prediccion struct {
+			Dia []struct {
+				ProbTormenta json.RawMessage `json:"probTormenta"`
+			} `json:"dia"`
+		} `json:"prediccion"`
+	}
+	if err := json.Unmarshal([]byte(raw), &forecasts); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := 0
+	for _, f := range forecasts {
+		for _, d := range f.Prediccion.Dia {
+			if d.ProbTormenta == nil {
+				continue
+			}
+			var storms []ProbTormenta
+			checkRoundTrip(t, d.ProbTormenta, &storms)
+			for _, s := range storms {
+				if !s.Value.Valid && len(s.Dato) == 0 {
+					t.Errorf("storm probability without value nor dato: %+v", s)
+				}
+			}
+			entries += len(storms)
+		}
+	}
+	if entries == 0 {
+		t.Error("no storm probabilities in the hourly forecast")
+	}
+}
+
+// checkRoundTrip decodes raw into v, encodes v back and reports every field
+// that was dropped or changed on the way.
+func checkRoundTrip(t *testing.T, raw []byte, v any) {
+	t.Helper()
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal(raw, &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range jsonDiff("$", normalizeJSON(want), normalizeJSON(got)) {
+		t.Error(d)
+	}
+}
+
+// normalizeJSON turns numeric strings into numbers, since AEMET quotes some
+// numbers and the models encode them back as JSON numbers.
+func normalizeJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+		return v
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonDiff returns the differences between two decoded JSON values.
+func jsonDiff(path string, want, got any) []string {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want object", path, got)}
+		}
+
+		var diffs []string
+		for _, k := range sortedKeys(w) {
+			gv, ok := g[k]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("%s.%s: dropped", path, k))
+				continue
+			}
+			diffs = append(diffs, jsonDiff(path+"."+k, w[k], gv)...)
+		}
+		for _, k := range sortedKeys(g) {
+			if _, ok := w[k]; !ok {
+				diffs = append(diffs, fmt.Sprintf("%s.%s: unexpected field", path, k))
+			}
+		}
+		return diffs
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want array", path, got)}
+		}
+		if len(g) != len(w) {
+			return []string{fmt.Sprintf("%s: got %d elements, want %d", path, len(g), len(w))}
+		}
+
+		var diffs []string
+		for i := range w {
+			diffs = append(diffs, jsonDiff(fmt.Sprintf("%s[%d]", path, i), w[i], g[i])...)
+		}
+		return diffs
+	default:
+		if want != got {
+			return []string{fmt.Sprintf("%s: got %v, want %v", path, got, want)}
+		}
+		return nil
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestMunicipalityValues(t *testing.T) {
+	m := loadForecastFixture(t)[0]
+
+	if zone, _ := m.Elaborado.Zone(); zone != "CEST" {
+		t.Errorf("Elaborado zone = %s, want CEST", zone)
+	}
+	if got := m.Elaborado.Format(aemetTimeLayout); got != "2025-05-20T09:01:56" {
+		t.Errorf("Elaborado = %s, want 2025-05-20T09:01:56", got)
+	}
+
+	dia := m.Prediccion.Dia[0]
+	if got := dia.ProbPrecipitacion[1].Periodo; got != (Period{Start: 0, End: 12}) {
+		t.Errorf("ProbPrecipitacion[1].Periodo = %+v, want {0 12}", got)
+	}
+	if got := dia.CotaNieveProv[0].Value; got != NewInt(2600) {
+		t.Errorf("CotaNieveProv[0] = %+v, want 2600", got)
+	}
+
+	racha := dia.RachaMax[1]
+	if racha.Periodo != (Period{Start: 0, End: 12}) {
+		t.Fatalf("RachaMax[1].Periodo = %+v, want {0 12}", racha.Periodo)
+	}
+	if racha.Value.Valid {
+		t.Errorf("RachaMax[1] = %+v, want missing value", racha.Value)
+	}
+	if got := dia.RachaMax[0].Value; got != NewInt(30) {
+		t.Errorf("RachaMax[0] = %+v, want 30", got)
+	}
+}
+
+// TestProbTormentaDato covers storm probabilities given as hourly dato values,
+// with a hand-written entry until an hourly forecast is recorded.
+func TestProbTormentaDato(t *testing.T) {
+	var storms []ProbTormenta
+	checkRoundTrip(t, []byte(`[{"value": 20, "periodo": "12-24", "dato": [{"value": 10, "hora": 15}, {"value": 35, "hora": 18}]}]`), &storms)
+
+	if len(storms) != 1 || len(storms[0].Dato) != 2 {
+		t.Fatalf("storms = %+v, want one entry with two dato values", storms)
+	}
+	if d := storms[0].Dato[1]; d.Value != NewInt(35) || d.Hora != 18 {
+		t.Errorf("dato = %+v, want 35 at 18h", d)
+	}
+}
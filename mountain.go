@@ -0,0 +1,198 @@
+package aemet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MountainArea is an AEMET mountain forecast area code
+type MountainArea string
+
+const (
+	MountainPicosDeEuropa    MountainArea = "peu1"
+	MountainPirineoNavarro   MountainArea = "nav1"
+	MountainPirineoAragones  MountainArea = "arn1"
+	MountainPirineoCatalan   MountainArea = "cat1"
+	MountainIbericaRiojana   MountainArea = "rio1"
+	MountainIbericaAragonesa MountainArea = "arn2"
+	MountainSierraDeMadrid   MountainArea = "mad2"
+	MountainSierraDeGredos   MountainArea = "gre1"
+	MountainSierraNevada     MountainArea = "nev1"
+)
+
+var mountainAreaNames = map[MountainArea]string{
+	MountainPicosDeEuropa:    "Picos de Europa",
+	MountainPirineoNavarro:   "Pirineo Navarro",
+	MountainPirineoAragones:  "Pirineo Aragonés",
+	MountainPirineoCatalan:   "Pirineo Catalán",
+	MountainIbericaRiojana:   "Ibérica Riojana",
+	MountainIbericaAragonesa: "Ibérica Aragonesa",
+	MountainSierraDeMadrid:   "Sierra de Madrid",
+	MountainSierraDeGredos:   "Sierra de Gredos",
+	MountainSierraNevada:     "Sierra Nevada",
+}
+
+// MountainAreas lists every mountain forecast area
+var MountainAreas = []MountainArea{
+	MountainPicosDeEuropa,
+	MountainPirineoNavarro,
+	MountainPirineoAragones,
+	MountainPirineoCatalan,
+	MountainIbericaRiojana,
+	MountainIbericaAragonesa,
+	MountainSierraDeMadrid,
+	MountainSierraDeGredos,
+	MountainSierraNevada,
+}
+
+// String returns the name of the mountain area
+func (a MountainArea) String() string {
+	if name, ok := mountainAreaNames[a]; ok {
+		return name
+	}
+	return string(a)
+}
+
+// Apartado represents a free-text section of a forecast
+type Apartado struct {
+	Nombre   string `json:"nombre"`
+	Cabecera string `json:"cabecera"`
+	Texto    string `json:"texto"`
+}
+
+// Parrafo represents a numbered paragraph of a forecast
+type Parrafo struct {
+	Numero Int    `json:"numero"`
+	Texto  string `json:"texto"`
+}
+
+// Lugar represents temperature forecasts for a reference location in a mountain area
+type Lugar struct {
+	Nombre  string `json:"nombre"`
+	Altitud string `json:"altitud"`
+	Minima  Int    `json:"minima"`
+	Maxima  Int    `json:"maxima"`
+}
+
+// SeccionMontana represents a section of a mountain forecast
+type SeccionMontana struct {
+	Nombre   string     `json:"nombre"`
+	Apartado []Apartado `json:"apartado"`
+	Lugar    []Lugar    `json:"lugar"`
+	Parrafo  []Parrafo  `json:"parrafo"`
+}
+
+// AltitudeWind represents the wind forecast at a given altitude
+type AltitudeWind struct {
+	// Altitude in meters, missing when the text does not state it.
+	Altitude Int
+	Text     string
+}
+
+// MountainForecast represents a mountain area forecast
+type MountainForecast struct {
+	Origen  Origen           `json:"origen"`
+	Nombre  string           `json:"nombre"`
+	ID      string           `json:"id"`
+	Seccion []SeccionMontana `json:"seccion"`
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus `json:"-"`
+}
+
+// Sections returns every free-text section of the forecast, in the order
+// AEMET lists them. Numbered paragraphs are returned as sections named after
+// the section they belong to.
+func (f *MountainForecast) Sections() []Apartado {
+	var texts []Apartado
+	for _, s := range f.Seccion {
+		texts = append(texts, s.Apartado...)
+		for _, p := range s.Parrafo {
+			texts = append(texts, Apartado{Nombre: s.Nombre, Texto: p.Texto})
+		}
+	}
+	return texts
+}
+
+var (
+	// freezingLevelRe matches the freezing level statement, e.g. "Isoterma de 0 ºC:
+	// en torno a 2.800 m" or "isoterma de 0ºC de 2600 a 3000 metros", capturing
+	// the first altitude. It stops at the end of the sentence.
+	freezingLevelRe = regexp.MustCompile(`(?i)(?:isoterma\s+de\s+0(?:\s*[º°])?(?:\s*c\b)?|isocero)[^.\d]{0,60}?(\d[\d.]*\d|\d)(?:\s*(?:a|y|-)\s*\d[\d.]*)?\s*m(?:etros)?\b`)
+	altitudeRe      = regexp.MustCompile(`(?i)(\d[\d.]*\d|\d)\s*m(?:etros)?\b`)
+)
+
+// parseMeters parses numbers like "2.400" or "2400" as meters
+func parseMeters(s string) Int {
+	v, err := strconv.Atoi(strings.ReplaceAll(s, ".", ""))
+	if err != nil {
+		return Int{}
+	}
+	return NewInt(v)
+}
+
+// FreezingLevel returns the freezing level (0 °C isotherm) in meters,
+// parsed from the forecast text. Valid is false if the text does not mention it.
+func (f *MountainForecast) FreezingLevel() Int {
+	for _, a := range f.Sections() {
+		if m := freezingLevelRe.FindStringSubmatch(a.Cabecera + " " + a.Texto); m != nil {
+			return parseMeters(m[1])
+		}
+	}
+	return Int{}
+}
+
+// WindAtAltitude returns the wind forecasts for free atmosphere altitudes,
+// taken from the sections whose header mentions the wind along with an
+// altitude or the free atmosphere. Surface wind sections are left out.
+func (f *MountainForecast) WindAtAltitude() []AltitudeWind {
+	var winds []AltitudeWind
+	for _, a := range f.Sections() {
+		header := strings.ToLower(a.Cabecera + " " + a.Nombre)
+		if !strings.Contains(header, "viento") {
+			continue
+		}
+
+		m := altitudeRe.FindStringSubmatch(a.Cabecera)
+		if m == nil && !strings.Contains(header, "atmósfera libre") && !strings.Contains(header, "atmosfera libre") {
+			continue
+		}
+
+		w := AltitudeWind{Text: strings.TrimSpace(a.Texto)}
+		if m != nil {
+			w.Altitude = parseMeters(m[1])
+		}
+		winds = append(winds, w)
+	}
+	return winds
+}
+
+// GetMountainForecast retrieves today's forecast for a mountain area.
+func (c *Client) GetMountainForecast(area MountainArea) (*MountainForecast, error) {
+	return c.getMountainForecast(area, fmt.Sprintf("api/prediccion/especifica/montaña/pasada/area/%s", string(area)))
+}
+
+// GetMountainForecastForDay retrieves the forecast for a mountain area and day,
+// where day is 0 for today up to 3 for three days ahead.
+func (c *Client) GetMountainForecastForDay(area MountainArea, day int) (*MountainForecast, error) {
+	if day < 0 || day > 3 {
+		return nil, fmt.Errorf("invalid mountain forecast day: %d", day)
+	}
+	return c.getMountainForecast(area, fmt.Sprintf("api/prediccion/especifica/montaña/pasada/area/%s/dia/%d", string(area), day))
+}
+
+func (c *Client) getMountainForecast(area MountainArea, path string) (*MountainForecast, error) {
+	var f []*MountainForecast
+	status, err := c.getRedirWithRetry(path, &f)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	if len(f) == 0 {
+		return nil, fmt.Errorf("no data found for mountain area %s", string(area))
+	}
+
+	f[0].Cache = status
+	return f[0], nil
+}
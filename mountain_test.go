@@ -0,0 +1,77 @@
+package aemet
+
+import "testing"
+
+func TestFreezingLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		cabecera string
+		texto    string
+		want     Int
+	}{
+		{"section", "Isoterma de 0ºC:", "En torno a 3200 metros.", NewInt(3200)},
+		{"degree sign apart", "Isoterma de 0 ºC:", "2.800 m, ascendiendo por la tarde.", NewInt(2800)},
+		{"range", "", "Isoterma de 0 ºC de 2600 a 3000 metros, en ascenso.", NewInt(2600)},
+		{"isocero", "", "Isocero sobre los 2400 m.", NewInt(2400)},
+		{"other isotherm", "Isoterma de -10ºC:", "En torno a 5200 m.", Int{}},
+		{"next sentence", "", "Isoterma de 0 ºC en ascenso. Viento a 3000 m del oeste.", Int{}},
+		{"not mentioned", "Temperaturas:", "En ligero ascenso, máximas de 12 ºC a 2000 m.", Int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &MountainForecast{Seccion: []SeccionMontana{{
+				Nombre:   "prediccion",
+				Apartado: []Apartado{{Cabecera: tt.cabecera, Texto: tt.texto}},
+			}}}
+			if got := f.FreezingLevel(); got != tt.want {
+				t.Errorf("FreezingLevel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindAtAltitude(t *testing.T) {
+	f := &MountainForecast{Seccion: []SeccionMontana{{
+		Nombre: "prediccion",
+		Apartado: []Apartado{
+			{Nombre: "viento", Cabecera: "Viento:", Texto: "Flojo variable en valles."},
+			{Nombre: "viento1500", Cabecera: "Viento en la atmósfera libre a 1500 m:", Texto: "Del sur, 10 a 20 km/h. "},
+			{Nombre: "viento3000", Cabecera: "Viento en la atmósfera libre a 3.000 metros:", Texto: "Oeste, 40 a 60 km/h."},
+			{Nombre: "vientoatm", Cabecera: "Viento en la atmósfera libre:", Texto: "Del noroeste, moderado."},
+			{Nombre: "sensacion", Cabecera: "Sensación térmica:", Texto: "Muy fría en cumbres por el viento."},
+		},
+	}}}
+
+	want := []AltitudeWind{
+		{Altitude: NewInt(1500), Text: "Del sur, 10 a 20 km/h."},
+		{Altitude: NewInt(3000), Text: "Oeste, 40 a 60 km/h."},
+		{Text: "Del noroeste, moderado."},
+	}
+	got := f.WindAtAltitude()
+	if len(got) != len(want) {
+		t.Fatalf("got %d winds, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wind %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMountainForecastPaths(t *testing.T) {
+	got := requestedPath(t, `[{}]`, func(c *Client) error {
+		_, err := c.GetMountainForecast(MountainSierraNevada)
+		return err
+	})
+	if want := "api/prediccion/especifica/montaña/pasada/area/nev1"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+
+	got = requestedPath(t, `[{}]`, func(c *Client) error {
+		_, err := c.GetMountainForecastForDay(MountainPicosDeEuropa, 2)
+		return err
+	})
+	if want := "api/prediccion/especifica/montaña/pasada/area/peu1/dia/2"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+}
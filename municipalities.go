@@ -98,11 +98,8 @@ func GetMunicipalityByID(id string) (*MunicipalityInfo, error) {
 		return nil, err
 	}
 
-	// Add "id" prefix if not present
-	searchID := id
-	if !strings.HasPrefix(searchID, "id") {
-		searchID = "id" + searchID
-	}
+	// IDs are stored without the "id" prefix
+	searchID := strings.TrimPrefix(id, "id")
 
 	for _, muni := range municipalities {
 		if muni.ID == searchID {
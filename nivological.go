@@ -0,0 +1,123 @@
+package aemet
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NivologicalArea is an AEMET nivological (snow and avalanche) bulletin area code
+type NivologicalArea string
+
+const (
+	NivologicalPirineoCatalan         NivologicalArea = "0"
+	NivologicalPirineoNavarroAragones NivologicalArea = "1"
+)
+
+// String returns the name of the nivological area
+func (a NivologicalArea) String() string {
+	switch a {
+	case NivologicalPirineoCatalan:
+		return "Pirineo Catalán"
+	case NivologicalPirineoNavarroAragones:
+		return "Pirineo Navarro y Aragonés"
+	default:
+		return string(a)
+	}
+}
+
+// AvalancheRisk is a level of the European avalanche danger scale.
+// AvalancheRiskUnknown means the bulletin does not state a level.
+type AvalancheRisk int
+
+const (
+	AvalancheRiskUnknown AvalancheRisk = iota
+	AvalancheRiskLow
+	AvalancheRiskModerate
+	AvalancheRiskConsiderable
+	AvalancheRiskHigh
+	AvalancheRiskVeryHigh
+)
+
+// String returns the Spanish name AEMET uses for the level
+func (r AvalancheRisk) String() string {
+	switch r {
+	case AvalancheRiskLow:
+		return "débil"
+	case AvalancheRiskModerate:
+		return "limitado"
+	case AvalancheRiskConsiderable:
+		return "notable"
+	case AvalancheRiskHigh:
+		return "fuerte"
+	case AvalancheRiskVeryHigh:
+		return "muy fuerte"
+	default:
+		return "desconocido"
+	}
+}
+
+// AvalancheBulletin represents an AEMET snow and avalanche bulletin
+type AvalancheBulletin struct {
+	Area NivologicalArea
+	// Text is the full bulletin, converted to UTF-8.
+	Text     string
+	Sections []TextSection
+	// RiskLevel is the highest avalanche danger level stated in the bulletin.
+	RiskLevel AvalancheRisk
+	// Cache tells whether the bulletin was served from the client cache.
+	Cache CacheStatus
+}
+
+const avalancheRiskPattern = `(muy fuerte|fuerte|notable|limitado|d[ée]bil)`
+
+var (
+	// avalancheLevelRe matches a level written on the danger scale, e.g. "notable (3)"
+	avalancheLevelRe = regexp.MustCompile(`(?i)\b` + avalancheRiskPattern + `\s*\(([1-5])\)`)
+	// avalancheStatementRe matches a level qualifying the danger itself, e.g.
+	// "peligro de aludes: notable" or "el riesgo será limitado"
+	avalancheStatementRe = regexp.MustCompile(`(?i)\b(?:peligro|riesgo)(?:\s+de\s+aludes)?(?:\s*:\s*|\s+(?:es|será|sera)\s+|\s+)(?:de\s+)?(?:nivel\s+)?` + avalancheRiskPattern + `\b`)
+)
+
+var avalancheRiskNames = map[string]AvalancheRisk{
+	"débil":      AvalancheRiskLow,
+	"debil":      AvalancheRiskLow,
+	"limitado":   AvalancheRiskModerate,
+	"notable":    AvalancheRiskConsiderable,
+	"fuerte":     AvalancheRiskHigh,
+	"muy fuerte": AvalancheRiskVeryHigh,
+}
+
+// parseAvalancheRisk returns the highest danger level stated in the bulletin.
+// Only levels written on the danger scale, like "limitado (2)", or directly
+// qualifying the danger, like "peligro notable", are taken into account, so
+// phrases such as "peligro por viento fuerte" are ignored.
+func parseAvalancheRisk(text string) AvalancheRisk {
+	risk := AvalancheRiskUnknown
+	text = strings.ToLower(text)
+	for _, m := range avalancheLevelRe.FindAllStringSubmatch(text, -1) {
+		risk = max(risk, AvalancheRisk(m[2][0]-'0'))
+	}
+	for _, m := range avalancheStatementRe.FindAllStringSubmatch(text, -1) {
+		risk = max(risk, avalancheRiskNames[m[1]])
+	}
+	return risk
+}
+
+// GetAvalancheBulletin retrieves the latest snow and avalanche bulletin for a nivological area.
+// The bulletin is published as plain text during the snow season; it is returned
+// split into sections along with the highest avalanche danger level it mentions.
+func (c *Client) GetAvalancheBulletin(area NivologicalArea) (*AvalancheBulletin, error) {
+	text, status, err := c.getRedirTextWithRetry(fmt.Sprintf("api/prediccion/especifica/nivologica/%s", string(area)))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	return &AvalancheBulletin{
+		Area:      area,
+		Text:      text,
+		Sections:  parseTextSections(text),
+		RiskLevel: parseAvalancheRisk(text),
+		Cache:     status,
+	}, nil
+}
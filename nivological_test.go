@@ -0,0 +1,135 @@
+package aemet
+
+import "testing"
+
+// bulletinPirineo follows the layout of the AEMET bulletins for the Pyrenees
+const bulletinPirineo = `AGENCIA ESTATAL DE METEOROLOGÍA
+BOLETÍN DE PELIGRO DE ALUDES
+PIRINEO NAVARRO Y ARAGONÉS
+Emitido a las 14:00 horas del miércoles 12 de febrero de 2025
+
+ESTADO DEL MANTO NIVOSO
+Espesores de 40 a 80 cm por encima de 1800 m en orientaciones norte.
+Placas de viento en orientaciones sur y este, por el viento fuerte del norte.
+
+PREDICCIÓN METEOROLÓGICA
+Viento fuerte del norte en cumbres, con peligro por rachas muy fuertes.
+Isoterma de 0 ºC en torno a 1500 m.
+
+ESTIMACIÓN DEL PELIGRO DE ALUDES
+Navarra: limitado (2) por encima de 1800 m.
+Aragón: notable (3) en Jacetania y Gállego, limitado (2) en el resto.
+`
+
+func TestParseAvalancheRisk(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want AvalancheRisk
+	}{
+		{"bulletin", bulletinPirineo, AvalancheRiskConsiderable},
+		{"upper case level", "NAVARRA: LIMITADO (2)\nARAGÓN: FUERTE (4)", AvalancheRiskHigh},
+		{"statement", "Peligro de aludes: notable por encima de 2000 m.", AvalancheRiskConsiderable},
+		{"statement with verb", "El riesgo será débil en todo el macizo.", AvalancheRiskLow},
+		{"very high", "Peligro muy fuerte en orientaciones norte.", AvalancheRiskVeryHigh},
+		{"wind", "Peligro por viento fuerte en crestas.", AvalancheRiskUnknown},
+		{"gusts", "Riesgo de rachas fuertes del norte.", AvalancheRiskUnknown},
+		{"no level", "Sin nieve suficiente para estimar el peligro.", AvalancheRiskUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAvalancheRisk(tt.text); got != tt.want {
+				t.Errorf("parseAvalancheRisk() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTextSections(t *testing.T) {
+	sections := parseTextSections(bulletinPirineo)
+
+	want := []string{
+		"AGENCIA ESTATAL DE METEOROLOGÍA",
+		"BOLETÍN DE PELIGRO DE ALUDES",
+		"PIRINEO NAVARRO Y ARAGONÉS",
+		"ESTADO DEL MANTO NIVOSO",
+		"PREDICCIÓN METEOROLÓGICA",
+		"ESTIMACIÓN DEL PELIGRO DE ALUDES",
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("got %d sections, want %d: %+v", len(sections), len(want), sections)
+	}
+	for i, title := range want {
+		if sections[i].Title != title {
+			t.Errorf("section %d title = %q, want %q", i, sections[i].Title, title)
+		}
+	}
+
+	if got := sections[2].Body; got != "Emitido a las 14:00 horas del miércoles 12 de febrero de 2025" {
+		t.Errorf("PIRINEO body = %q", got)
+	}
+	if got := sections[5].Body; got != "Navarra: limitado (2) por encima de 1800 m.\nAragón: notable (3) en Jacetania y Gállego, limitado (2) en el resto." {
+		t.Errorf("ESTIMACIÓN body = %q", got)
+	}
+}
+
+func TestParseTextSectionsPreamble(t *testing.T) {
+	text := "Predicción válida para hoy.\r\n\r\nCIELO\r\nPoco nuboso.\r\n\r\nAumentando a nuboso.\r\nPELIGRO DE ALUDES\r\nNAVARRA: LIMITADO (2)\r\n"
+
+	sections := parseTextSections(text)
+	want := []TextSection{
+		{Body: "Predicción válida para hoy."},
+		{Title: "CIELO", Body: "Poco nuboso.\n\nAumentando a nuboso."},
+		{Title: "PELIGRO DE ALUDES", Body: "NAVARRA: LIMITADO (2)"},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("got %+v, want %+v", sections, want)
+	}
+	for i := range want {
+		if sections[i] != want[i] {
+			t.Errorf("section %d = %+v, want %+v", i, sections[i], want[i])
+		}
+	}
+}
+
+func TestIsHeading(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"ESTADO DEL MANTO NIVOSO", true},
+		{"  PREDICCIÓN PARA EL DÍA 13  ", true},
+		{"ESTIMACIÓN DEL PELIGRO:", true},
+		{"VÁLIDO HASTA LAS 24:00 HORAS", true},
+		{"Navarra: limitado (2)", false},
+		{"NAVARRA: LIMITADO (2)", false},
+		{"24 H", false},
+		{"", false},
+		{"00-12 UTC", false},
+	}
+	for _, tt := range tests {
+		if got := isHeading(tt.line); got != tt.want {
+			t.Errorf("isHeading(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	// "Aragón 5 €" in ISO-8859-15
+	if got := decodeText([]byte{'A', 'r', 'a', 'g', 0xF3, 'n', ' ', '5', ' ', 0xA4}); got != "Aragón 5 €" {
+		t.Errorf("decodeText() = %q", got)
+	}
+	if got := decodeText([]byte("Aragón")); got != "Aragón" {
+		t.Errorf("decodeText() = %q", got)
+	}
+}
+
+func TestAvalancheBulletinPath(t *testing.T) {
+	got := requestedPath(t, bulletinPirineo, func(c *Client) error {
+		_, err := c.GetAvalancheBulletin(NivologicalPirineoNavarroAragones)
+		return err
+	})
+	if want := "api/prediccion/especifica/nivologica/1"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package aemet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Int is an integer value decoded from AEMET JSON.
+// AEMET is inconsistent about numeric encodings: the same field may arrive as a
+// number, a numeric string, a string using a decimal comma, or an empty string
+// when the value is not available. Valid is false when the value was missing.
+type Int struct {
+	Value int
+	Valid bool
+}
+
+// Float is a floating point value decoded from AEMET JSON.
+// It accepts the same encodings as Int. Valid is false when the value was missing.
+type Float struct {
+	Value float64
+	Valid bool
+}
+
+// NewInt returns a valid Int holding v.
+func NewInt(v int) Int {
+	return Int{Value: v, Valid: true}
+}
+
+// NewFloat returns a valid Float holding v.
+func NewFloat(v float64) Float {
+	return Float{Value: v, Valid: true}
+}
+
+// String returns the value as a decimal string, or an empty string if missing.
+func (n Int) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.Itoa(n.Value)
+}
+
+// String returns the value as a decimal string, or an empty string if missing.
+func (n Float) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatFloat(n.Value, 'f', -1, 64)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Int) UnmarshalJSON(b []byte) error {
+	f, ok, err := parseNumber(b)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*n = Int{}
+		return nil
+	}
+	*n = NewInt(int(math.Round(f)))
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Missing values are encoded as an empty string, as AEMET does.
+func (n Int) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte(`""`), nil
+	}
+	return strconv.AppendInt(nil, int64(n.Value), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Float) UnmarshalJSON(b []byte) error {
+	f, ok, err := parseNumber(b)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*n = Float{}
+		return nil
+	}
+	*n = NewFloat(f)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Missing values are encoded as an empty string, as AEMET does.
+func (n Float) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte(`""`), nil
+	}
+	return strconv.AppendFloat(nil, n.Value, 'f', -1, 64), nil
+}
+
+// parseNumber decodes a JSON number, numeric string or null.
+// It reports ok=false for null and empty or blank strings.
+func parseNumber(b []byte) (float64, bool, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return 0, false, nil
+	}
+
+	if b[0] != '"' {
+		f, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid number: %s", b)
+		}
+		return f, true, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return 0, false, fmt.Errorf("error decoding number: %w", err)
+	}
+	return parseNumberString(s)
+}
+
+// parseNumberString parses a numeric string, accepting a decimal comma.
+// It reports ok=false for empty or blank strings.
+func parseNumberString(s string) (float64, bool, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid number: %q", s)
+	}
+
+	return f, true, nil
+}
+
+// parseInt parses a numeric string as an Int, see parseNumberString.
+func parseInt(s string) (Int, error) {
+	f, ok, err := parseNumberString(s)
+	if err != nil || !ok {
+		return Int{}, err
+	}
+	return NewInt(int(math.Round(f))), nil
+}
+
+// parseFloat parses a numeric string as a Float, see parseNumberString.
+func parseFloat(s string) (Float, error) {
+	f, ok, err := parseNumberString(s)
+	if err != nil || !ok {
+		return Float{}, err
+	}
+	return NewFloat(f), nil
+}
+
+// Code is an identifier decoded from AEMET JSON, which AEMET sends either
+// as a string or as a number depending on the product.
+type Code string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Code) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		*c = ""
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("error decoding code: %w", err)
+		}
+		*c = Code(s)
+		return nil
+	}
+	*c = Code(b)
+	return nil
+}
@@ -0,0 +1,117 @@
+package aemet
+
+import "fmt"
+
+// RadarSite is an AEMET regional weather radar code.
+// Codes follow the ones AEMET uses on its radar pages.
+type RadarSite string
+
+const (
+	RadarAlmeria     RadarSite = "am"
+	RadarAsturias    RadarSite = "as"
+	RadarBarcelona   RadarSite = "ba"
+	RadarCaceres     RadarSite = "cc"
+	RadarACoruna     RadarSite = "co"
+	RadarGranCanaria RadarSite = "lp"
+	RadarMadrid      RadarSite = "ma"
+	RadarMalaga      RadarSite = "ml"
+	RadarMurcia      RadarSite = "mu"
+	RadarPalma       RadarSite = "pm"
+	RadarSevilla     RadarSite = "se"
+	RadarBizkaia     RadarSite = "ss"
+	RadarValencia    RadarSite = "va"
+	RadarValladolid  RadarSite = "vd"
+	RadarZaragoza    RadarSite = "za"
+)
+
+var radarSiteNames = map[RadarSite]string{
+	RadarAlmeria:     "Almería",
+	RadarAsturias:    "Asturias",
+	RadarBarcelona:   "Barcelona",
+	RadarCaceres:     "Cáceres",
+	RadarACoruna:     "A Coruña",
+	RadarGranCanaria: "Gran Canaria",
+	RadarMadrid:      "Madrid",
+	RadarMalaga:      "Málaga",
+	RadarMurcia:      "Murcia",
+	RadarPalma:       "Palma",
+	RadarSevilla:     "Sevilla",
+	RadarBizkaia:     "Bizkaia",
+	RadarValencia:    "Valencia",
+	RadarValladolid:  "Valladolid",
+	RadarZaragoza:    "Zaragoza",
+}
+
+// RadarSites lists every regional radar
+var RadarSites = []RadarSite{
+	RadarAlmeria, RadarAsturias, RadarBarcelona, RadarCaceres, RadarACoruna,
+	RadarGranCanaria, RadarMadrid, RadarMalaga, RadarMurcia, RadarPalma,
+	RadarSevilla, RadarBizkaia, RadarValencia, RadarValladolid, RadarZaragoza,
+}
+
+// String returns the name of the radar site
+func (r RadarSite) String() string {
+	if name, ok := radarSiteNames[r]; ok {
+		return name
+	}
+	return string(r)
+}
+
+// SatelliteProduct is an AEMET satellite image product
+type SatelliteProduct string
+
+const (
+	// SatelliteNDVI is the normalized difference vegetation index.
+	// AEMET spells the product "nvdi" in its API paths.
+	SatelliteNDVI SatelliteProduct = "nvdi"
+	// SatelliteSST is the sea surface temperature.
+	SatelliteSST SatelliteProduct = "sst"
+)
+
+// String returns the name of the satellite product
+func (p SatelliteProduct) String() string {
+	switch p {
+	case SatelliteNDVI:
+		return "Índice normalizado de vegetación"
+	case SatelliteSST:
+		return "Temperatura del agua del mar"
+	default:
+		return string(p)
+	}
+}
+
+// GetNationalRadar retrieves the latest national radar composite image.
+func (c *Client) GetNationalRadar() (*Image, error) {
+	img, err := c.getRedirImageWithRetry("api/red/radar/nacional")
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+	return img, nil
+}
+
+// GetRegionalRadar retrieves the latest image of a regional radar.
+func (c *Client) GetRegionalRadar(site RadarSite) (*Image, error) {
+	img, err := c.getRedirImageWithRetry(fmt.Sprintf("api/red/radar/regional/%s", string(site)))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+	return img, nil
+}
+
+// GetSatelliteImage retrieves the latest image of a satellite product.
+func (c *Client) GetSatelliteImage(product SatelliteProduct) (*Image, error) {
+	img, err := c.getRedirImageWithRetry(fmt.Sprintf("api/satelites/producto/%s", string(product)))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+	return img, nil
+}
+
+// GetLightningMap retrieves the latest lightning strike map image.
+func (c *Client) GetLightningMap() (*Image, error) {
+	img, err := c.getRedirImageWithRetry("api/red/rayos/mapa")
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+	return img, nil
+}
@@ -0,0 +1,23 @@
+package aemet
+
+import "testing"
+
+func TestImagePaths(t *testing.T) {
+	for _, c := range []struct {
+		call func(c *Client) error
+		want string
+	}{
+		{func(c *Client) error {
+			_, err := c.GetRegionalRadar(RadarGranCanaria)
+			return err
+		}, "api/red/radar/regional/lp"},
+		{func(c *Client) error {
+			_, err := c.GetSatelliteImage(SatelliteSST)
+			return err
+		}, "api/satelites/producto/sst"},
+	} {
+		if got := requestedPath(t, "GIF89a", c.call); got != c.want {
+			t.Errorf("path = %q, want %q", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package aemet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// requestedPath runs call against a client answering every request with datos
+// and returns the API path of the first metadata request
+func requestedPath(t *testing.T, datos string, call func(c *Client) error) string {
+	t.Helper()
+
+	var path string
+	client, err := New(Config{
+		AemetApiKey: "test",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, "/sh/") {
+				return jsonResponse(http.StatusOK, datos), nil
+			}
+			if path == "" {
+				path = strings.TrimPrefix(req.URL.Path, "/opendata/")
+			}
+			return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/datos"}`), nil
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := call(client); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// forecastServer answers municipality forecast requests with the hand-built
+// Madrid forecast, whatever the municipality
+func forecastServer(t *testing.T) roundTripFunc {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/prediccion_diaria_28079.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/sh/") {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewReader(fixture)),
+			}, nil
+		}
+		return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/forecast"}`), nil
+	}
+}
+
+func TestLimiterSpacing(t *testing.T) {
+	l := newLimiter(1200) // one request every 50ms
+
+	start := time.Now()
+	for range 4 {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("4 requests took %s, want at least 150ms", elapsed)
+	}
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	l := newLimiter(1) // one request per minute
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() = %v, want deadline exceeded", err)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	if l := newLimiter(0); l != nil {
+		t.Fatalf("newLimiter(0) = %v, want nil", l)
+	}
+	var l *limiter
+	if err := l.wait(context.Background()); err != nil {
+		t.Errorf("wait() = %v", err)
+	}
+}
+
+func TestGetForecastsRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var sent []time.Time
+	server := forecastServer(t)
+
+	client, err := New(Config{
+		AemetApiKey: "test",
+		RateLimit:   3000, // one request every 20ms
+		Concurrency: 8,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sent = append(sent, time.Now())
+			mu.Unlock()
+			return server(req)
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range client.GetForecasts(context.Background(), []string{"28079", "08019", "46250", "41091", "48020"}) {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.ID, r.Err)
+		}
+	}
+
+	if len(sent) != 10 {
+		t.Fatalf("sent %d requests, want 10", len(sent))
+	}
+	// Requests are spaced evenly, allowing for timer jitter
+	if elapsed := sent[len(sent)-1].Sub(sent[0]); elapsed < 9*20*time.Millisecond-10*time.Millisecond {
+		t.Errorf("10 requests sent in %s, want at least 180ms", elapsed)
+	}
+}
+
+func TestRateLimitedRetry(t *testing.T) {
+	defer func(d time.Duration) { rateLimitBackoff = d }(rateLimitBackoff)
+	rateLimitBackoff = 5 * time.Millisecond
+
+	server := forecastServer(t)
+	var calls int
+	var backoffs []time.Duration
+
+	client, err := New(Config{
+		AemetApiKey: "test",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			switch calls {
+			case 1:
+				return jsonResponse(http.StatusTooManyRequests, `{"descripcion": "Límite de peticiones o caudal por minuto excedido para este usuario", "estado": 429}`), nil
+			case 2:
+				return jsonResponse(http.StatusTooManyRequests, `Too Many Requests`), nil
+			}
+			return server(req)
+		})},
+		Hooks: []Hook{{OnRetry: func(ctx context.Context, info RetryInfo) {
+			backoffs = append(backoffs, info.Backoff)
+		}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := client.GetForecastFor("28079")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Nombre != "Madrid" {
+		t.Errorf("Nombre = %q, want Madrid", m.Nombre)
+	}
+
+	want := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond}
+	if len(backoffs) != len(want) || backoffs[0] != want[0] || backoffs[1] != want[1] {
+		t.Errorf("backoffs = %v, want %v", backoffs, want)
+	}
+}
+
+func TestStationsRetryPolicy(t *testing.T) {
+	defer func(d time.Duration) { rateLimitBackoff = d }(rateLimitBackoff)
+	rateLimitBackoff = time.Millisecond
+
+	// Both entry points to the station inventory retry the same way
+	for name, fetch := range map[string]func(*Client) error{
+		"GetStations": func(c *Client) error {
+			_, err := c.GetStations()
+			return err
+		},
+		"FindStations": func(c *Client) error {
+			_, err := c.FindStations(StationFilter{})
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls, retries int
+			client, err := New(Config{
+				AemetApiKey: "test",
+				HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if strings.Contains(req.URL.Path, "/sh/") {
+						return jsonResponse(http.StatusOK, `[{"indicativo": "3195", "nombre": "MADRID, RETIRO"}]`), nil
+					}
+					calls++
+					if calls <= 2 {
+						return jsonResponse(http.StatusTooManyRequests, `{"descripcion": "Límite de peticiones o caudal por minuto excedido para este usuario", "estado": 429}`), nil
+					}
+					return jsonResponse(http.StatusOK, `{"estado": 200, "datos": "https://opendata.aemet.es/opendata/sh/stations"}`), nil
+				})},
+				Hooks: []Hook{{OnRetry: func(ctx context.Context, info RetryInfo) {
+					retries++
+				}}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := fetch(client); err != nil {
+				t.Fatal(err)
+			}
+			if retries != 2 {
+				t.Errorf("%d retries, want 2", retries)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package aemet
+
+import (
+	"slices"
+	"strings"
+)
+
+// Community is an AEMET autonomous community (CCAA) code
+type Community string
+
+const (
+	CommunityAndalucia        Community = "and"
+	CommunityAragon           Community = "arn"
+	CommunityAsturias         Community = "ast"
+	CommunityIllesBalears     Community = "bal"
+	CommunityCanarias         Community = "coo"
+	CommunityCantabria        Community = "can"
+	CommunityCastillaLaMancha Community = "clm"
+	CommunityCastillaYLeon    Community = "cle"
+	CommunityCataluna         Community = "cat"
+	CommunityValenciana       Community = "val"
+	CommunityExtremadura      Community = "ext"
+	CommunityGalicia          Community = "gal"
+	CommunityMadrid           Community = "mad"
+	CommunityMurcia           Community = "mur"
+	CommunityNavarra          Community = "nav"
+	CommunityPaisVasco        Community = "pva"
+	CommunityLaRioja          Community = "rio"
+	CommunityCeuta            Community = "ceu"
+	CommunityMelilla          Community = "mel"
+)
+
+var communityNames = map[Community]string{
+	CommunityAndalucia:        "Andalucía",
+	CommunityAragon:           "Aragón",
+	CommunityAsturias:         "Principado de Asturias",
+	CommunityIllesBalears:     "Illes Balears",
+	CommunityCanarias:         "Canarias",
+	CommunityCantabria:        "Cantabria",
+	CommunityCastillaLaMancha: "Castilla-La Mancha",
+	CommunityCastillaYLeon:    "Castilla y León",
+	CommunityCataluna:         "Cataluña",
+	CommunityValenciana:       "Comunitat Valenciana",
+	CommunityExtremadura:      "Extremadura",
+	CommunityGalicia:          "Galicia",
+	CommunityMadrid:           "Comunidad de Madrid",
+	CommunityMurcia:           "Región de Murcia",
+	CommunityNavarra:          "Comunidad Foral de Navarra",
+	CommunityPaisVasco:        "País Vasco",
+	CommunityLaRioja:          "La Rioja",
+	CommunityCeuta:            "Ceuta",
+	CommunityMelilla:          "Melilla",
+}
+
+// Communities lists every autonomous community
+var Communities = []Community{
+	CommunityAndalucia, CommunityAragon, CommunityAsturias, CommunityIllesBalears,
+	CommunityCanarias, CommunityCantabria, CommunityCastillaLaMancha, CommunityCastillaYLeon,
+	CommunityCataluna, CommunityValenciana, CommunityExtremadura, CommunityGalicia,
+	CommunityMadrid, CommunityMurcia, CommunityNavarra, CommunityPaisVasco,
+	CommunityLaRioja, CommunityCeuta, CommunityMelilla,
+}
+
+// String returns the name of the community
+func (c Community) String() string {
+	if name, ok := communityNames[c]; ok {
+		return name
+	}
+	return string(c)
+}
+
+// Province is a province code, as used by INE and AEMET
+type Province string
+
+const (
+	ProvinceAraba               Province = "01"
+	ProvinceAlbacete            Province = "02"
+	ProvinceAlicante            Province = "03"
+	ProvinceAlmeria             Province = "04"
+	ProvinceAvila               Province = "05"
+	ProvinceBadajoz             Province = "06"
+	ProvinceIllesBalears        Province = "07"
+	ProvinceBarcelona           Province = "08"
+	ProvinceBurgos              Province = "09"
+	ProvinceCaceres             Province = "10"
+	ProvinceCadiz               Province = "11"
+	ProvinceCastellon           Province = "12"
+	ProvinceCiudadReal          Province = "13"
+	ProvinceCordoba             Province = "14"
+	ProvinceACoruna             Province = "15"
+	ProvinceCuenca              Province = "16"
+	ProvinceGirona              Province = "17"
+	ProvinceGranada             Province = "18"
+	ProvinceGuadalajara         Province = "19"
+	ProvinceGipuzkoa            Province = "20"
+	ProvinceHuelva              Province = "21"
+	ProvinceHuesca              Province = "22"
+	ProvinceJaen                Province = "23"
+	ProvinceLeon                Province = "24"
+	ProvinceLleida              Province = "25"
+	ProvinceLaRioja             Province = "26"
+	ProvinceLugo                Province = "27"
+	ProvinceMadrid              Province = "28"
+	ProvinceMalaga              Province = "29"
+	ProvinceMurcia              Province = "30"
+	ProvinceNavarra             Province = "31"
+	ProvinceOurense             Province = "32"
+	ProvinceAsturias            Province = "33"
+	ProvincePalencia            Province = "34"
+	ProvinceLasPalmas           Province = "35"
+	ProvincePontevedra          Province = "36"
+	ProvinceSalamanca           Province = "37"
+	ProvinceSantaCruzDeTenerife Province = "38"
+	ProvinceCantabria           Province = "39"
+	ProvinceSegovia             Province = "40"
+	ProvinceSevilla             Province = "41"
+	ProvinceSoria               Province = "42"
+	ProvinceTarragona           Province = "43"
+	ProvinceTeruel              Province = "44"
+	ProvinceToledo              Province = "45"
+	ProvinceValencia            Province = "46"
+	ProvinceValladolid          Province = "47"
+	ProvinceBizkaia             Province = "48"
+	ProvinceZamora              Province = "49"
+	ProvinceZaragoza            Province = "50"
+	ProvinceCeuta               Province = "51"
+	ProvinceMelilla             Province = "52"
+)
+
+type provinceInfo struct {
+	name      string
+	community Community
+}
+
+var provinceInfos = map[Province]provinceInfo{
+	ProvinceAraba:               {"Araba/Álava", CommunityPaisVasco},
+	ProvinceAlbacete:            {"Albacete", CommunityCastillaLaMancha},
+	ProvinceAlicante:            {"Alicante/Alacant", CommunityValenciana},
+	ProvinceAlmeria:             {"Almería", CommunityAndalucia},
+	ProvinceAvila:               {"Ávila", CommunityCastillaYLeon},
+	ProvinceBadajoz:             {"Badajoz", CommunityExtremadura},
+	ProvinceIllesBalears:        {"Illes Balears", CommunityIllesBalears},
+	ProvinceBarcelona:           {"Barcelona", CommunityCataluna},
+	ProvinceBurgos:              {"Burgos", CommunityCastillaYLeon},
+	ProvinceCaceres:             {"Cáceres", CommunityExtremadura},
+	ProvinceCadiz:               {"Cádiz", CommunityAndalucia},
+	ProvinceCastellon:           {"Castellón/Castelló", CommunityValenciana},
+	ProvinceCiudadReal:          {"Ciudad Real", CommunityCastillaLaMancha},
+	ProvinceCordoba:             {"Córdoba", CommunityAndalucia},
+	ProvinceACoruna:             {"A Coruña", CommunityGalicia},
+	ProvinceCuenca:              {"Cuenca", CommunityCastillaLaMancha},
+	ProvinceGirona:              {"Girona", CommunityCataluna},
+	ProvinceGranada:             {"Granada", CommunityAndalucia},
+	ProvinceGuadalajara:         {"Guadalajara", CommunityCastillaLaMancha},
+	ProvinceGipuzkoa:            {"Gipuzkoa", CommunityPaisVasco},
+	ProvinceHuelva:              {"Huelva", CommunityAndalucia},
+	ProvinceHuesca:              {"Huesca", CommunityAragon},
+	ProvinceJaen:                {"Jaén", CommunityAndalucia},
+	ProvinceLeon:                {"León", CommunityCastillaYLeon},
+	ProvinceLleida:              {"Lleida", CommunityCataluna},
+	ProvinceLaRioja:             {"La Rioja", CommunityLaRioja},
+	ProvinceLugo:                {"Lugo", CommunityGalicia},
+	ProvinceMadrid:              {"Madrid", CommunityMadrid},
+	ProvinceMalaga:              {"Málaga", CommunityAndalucia},
+	ProvinceMurcia:              {"Murcia", CommunityMurcia},
+	ProvinceNavarra:             {"Navarra", CommunityNavarra},
+	ProvinceOurense:             {"Ourense", CommunityGalicia},
+	ProvinceAsturias:            {"Asturias", CommunityAsturias},
+	ProvincePalencia:            {"Palencia", CommunityCastillaYLeon},
+	ProvinceLasPalmas:           {"Las Palmas", CommunityCanarias},
+	ProvincePontevedra:          {"Pontevedra", CommunityGalicia},
+	ProvinceSalamanca:           {"Salamanca", CommunityCastillaYLeon},
+	ProvinceSantaCruzDeTenerife: {"Santa Cruz de Tenerife", CommunityCanarias},
+	ProvinceCantabria:           {"Cantabria", CommunityCantabria},
+	ProvinceSegovia:             {"Segovia", CommunityCastillaYLeon},
+	ProvinceSevilla:             {"Sevilla", CommunityAndalucia},
+	ProvinceSoria:               {"Soria", CommunityCastillaYLeon},
+	ProvinceTarragona:           {"Tarragona", CommunityCataluna},
+	ProvinceTeruel:              {"Teruel", CommunityAragon},
+	ProvinceToledo:              {"Toledo", CommunityCastillaLaMancha},
+	ProvinceValencia:            {"Valencia/València", CommunityValenciana},
+	ProvinceValladolid:          {"Valladolid", CommunityCastillaYLeon},
+	ProvinceBizkaia:             {"Bizkaia", CommunityPaisVasco},
+	ProvinceZamora:              {"Zamora", CommunityCastillaYLeon},
+	ProvinceZaragoza:            {"Zaragoza", CommunityAragon},
+	ProvinceCeuta:               {"Ceuta", CommunityCeuta},
+	ProvinceMelilla:             {"Melilla", CommunityMelilla},
+}
+
+// Provinces lists every province, ordered by code
+var Provinces = func() []Province {
+	provinces := make([]Province, 0, len(provinceInfos))
+	for p := range provinceInfos {
+		provinces = append(provinces, p)
+	}
+	slices.Sort(provinces)
+	return provinces
+}()
+
+// String returns the name of the province
+func (p Province) String() string {
+	if info, ok := provinceInfos[p]; ok {
+		return info.name
+	}
+	return string(p)
+}
+
+// Community returns the autonomous community the province belongs to
+func (p Province) Community() Community {
+	return provinceInfos[p].community
+}
+
+// Provinces returns every province of the community
+func (c Community) Provinces() []Province {
+	var provinces []Province
+	for _, p := range Provinces {
+		if provinceInfos[p].community == c {
+			provinces = append(provinces, p)
+		}
+	}
+	return provinces
+}
+
+// ProvinceOf returns the province of a municipality, from the first two
+// digits of its ID.
+func ProvinceOf(municipalityID string) Province {
+	id := strings.TrimPrefix(municipalityID, "id")
+	if len(id) == 4 {
+		// IDs from provinces 01-09 sometimes lose their leading zero
+		id = "0" + id
+	}
+	if len(id) < 2 {
+		return ""
+	}
+	return Province(id[:2])
+}
@@ -0,0 +1,222 @@
+// Package replay records AEMET API exchanges into fixture files and serves
+// them back, so code using the client can run without network or API key.
+//
+// Record real exchanges once:
+//
+//	client, err := aemet.New(aemet.Config{
+//		HTTPClient: &http.Client{Transport: &replay.Recorder{Dir: "testdata/fixtures"}},
+//	})
+//
+// and replay them later:
+//
+//	r, err := replay.NewReplayer("testdata/fixtures")
+//	client, err := aemet.New(aemet.Config{
+//		AemetApiKey: "replay",
+//		HTTPClient:  &http.Client{Transport: r},
+//	})
+//
+// Both legs of every call are recorded: the metadata request and the request
+// to the datos URL it returns. The api_key query parameter is removed from
+// recorded URLs, so fixtures can be committed.
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrNoFixture is returned by Replayer for requests that were not recorded.
+var ErrNoFixture = errors.New("no fixture")
+
+// Fixture is a recorded HTTP exchange
+type Fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	// Body holds the response body when it is valid UTF-8, and BodyBase64
+	// otherwise, e.g. for images or ISO-8859-15 text.
+	Body       string `json:"body,omitempty"`
+	BodyBase64 []byte `json:"body_base64,omitempty"`
+}
+
+// ScrubURL returns rawURL without its api_key query parameter.
+func ScrubURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	q.Del("api_key")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// FileName returns the fixture file name for a request, derived from its
+// scrubbed URL.
+func FileName(method, rawURL string) string {
+	u := ScrubURL(rawURL)
+	name := strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+
+	// Keep names readable but unique and within file system limits
+	sum := sha256.Sum256([]byte(method + " " + u))
+	if len(name) > 150 {
+		name = name[:150]
+	}
+	return fmt.Sprintf("%s_%s_%s.json", strings.ToLower(method), name, hex.EncodeToString(sum[:4]))
+}
+
+// Recorder is an http.RoundTripper that performs requests through Transport
+// and writes each exchange to a fixture file in Dir.
+type Recorder struct {
+	Dir string
+	// Transport performs the requests. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := Fixture{
+		Method: req.Method,
+		URL:    ScrubURL(req.URL.String()),
+		Status: resp.StatusCode,
+		Header: resp.Header,
+	}
+	if utf8.Valid(body) {
+		f.Body = string(body)
+	} else {
+		f.BodyBase64 = body
+	}
+
+	if err := writeFixture(r.Dir, f); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func writeFixture(dir string, f Fixture) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating fixture directory: %w", err)
+	}
+
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding fixture: %w", err)
+	}
+
+	path := filepath.Join(dir, FileName(f.Method, f.URL))
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("error writing fixture: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an http.RoundTripper that serves recorded fixtures.
+// Requests without a fixture fail.
+type Replayer struct {
+	mu       sync.RWMutex
+	fixtures map[string]Fixture
+}
+
+// NewReplayer loads every fixture in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Replayer{fixtures: make(map[string]Fixture, len(paths))}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading fixture: %w", err)
+		}
+
+		var f Fixture
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("error decoding fixture %s: %w", path, err)
+		}
+		r.Add(f)
+	}
+
+	return r, nil
+}
+
+// Add registers a fixture, replacing any other for the same request.
+func (r *Replayer) Add(f Fixture) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fixtures == nil {
+		r.fixtures = make(map[string]Fixture)
+	}
+	r.fixtures[fixtureKey(f.Method, f.URL)] = f
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.RLock()
+	f, ok := r.fixtures[fixtureKey(req.Method, req.URL.String())]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoFixture, req.Method, ScrubURL(req.URL.String()))
+	}
+
+	body := f.BodyBase64
+	if body == nil {
+		body = []byte(f.Body)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        f.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func fixtureKey(method, rawURL string) string {
+	return method + " " + ScrubURL(rawURL)
+}
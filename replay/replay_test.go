@@ -0,0 +1,100 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorderScrubsAPIKey(t *testing.T) {
+	dir := t.TempDir()
+	rec := &Recorder{Dir: dir, Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/plain;charset=ISO-8859-15"}},
+			Body:       io.NopCloser(strings.NewReader("PREDICCI\xd3N")),
+		}, nil
+	})}
+
+	const rawURL = "https://opendata.aemet.es/opendata/api/prediccion/nacional/hoy?api_key=secret"
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := io.ReadAll(resp.Body); string(b) != "PREDICCI\xd3N" {
+		t.Errorf("response body = %q", b)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("recorded %d fixtures, want 1", len(paths))
+	}
+	if name := filepath.Base(paths[0]); name != FileName(http.MethodGet, rawURL) || strings.Contains(name, "secret") {
+		t.Errorf("fixture name = %s", name)
+	}
+
+	b, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "secret") {
+		t.Errorf("fixture contains the API key: %s", b)
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(b, &f); err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://opendata.aemet.es/opendata/api/prediccion/nacional/hoy"; f.URL != want {
+		t.Errorf("URL = %s, want %s", f.URL, want)
+	}
+	if f.Body != "" || string(f.BodyBase64) != "PREDICCI\xd3N" {
+		t.Errorf("body = %q, base64 body = %q", f.Body, f.BodyBase64)
+	}
+}
+
+func TestReplayerIgnoresAPIKey(t *testing.T) {
+	r := &Replayer{}
+	r.Add(Fixture{
+		Method: http.MethodGet,
+		URL:    "https://opendata.aemet.es/opendata/sh/5b3b1e0c",
+		Status: http.StatusOK,
+		Body:   `{"estado": 200}`,
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "https://opendata.aemet.es/opendata/sh/5b3b1e0c?api_key=replay", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := io.ReadAll(resp.Body); string(b) != `{"estado": 200}` {
+		t.Errorf("body = %q", b)
+	}
+
+	req.URL.Path = "/opendata/sh/unknown"
+	_, err = r.RoundTrip(req)
+	if !errors.Is(err, ErrNoFixture) || strings.Contains(err.Error(), "replay") {
+		t.Errorf("RoundTrip() = %v, want ErrNoFixture without the API key", err)
+	}
+}
@@ -0,0 +1,293 @@
+package aemet
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Condition is a normalized weather condition derived from an AEMET sky state code.
+type Condition int
+
+const (
+	ConditionUnknown Condition = iota
+	ConditionClear
+	ConditionPartlyCloudy
+	ConditionCloudy
+	ConditionOvercast
+	ConditionHighClouds
+	ConditionLightRain
+	ConditionRain
+	ConditionLightSnow
+	ConditionSnow
+	ConditionStorm
+	ConditionFog
+	ConditionMist
+	ConditionHaze
+)
+
+var conditionNames = map[Condition]string{
+	ConditionUnknown:      "unknown",
+	ConditionClear:        "clear",
+	ConditionPartlyCloudy: "partly cloudy",
+	ConditionCloudy:       "cloudy",
+	ConditionOvercast:     "overcast",
+	ConditionHighClouds:   "high clouds",
+	ConditionLightRain:    "light rain",
+	ConditionRain:         "rain",
+	ConditionLightSnow:    "light snow",
+	ConditionSnow:         "snow",
+	ConditionStorm:        "storm",
+	ConditionFog:          "fog",
+	ConditionMist:         "mist",
+	ConditionHaze:         "haze",
+}
+
+// String returns the English name of the condition.
+func (c Condition) String() string {
+	if name, ok := conditionNames[c]; ok {
+		return name
+	}
+	return conditionNames[ConditionUnknown]
+}
+
+// Severity ranks how disruptive a sky state is, from SeverityNone to SeverityHigh.
+type Severity int
+
+const (
+	SeverityNone Severity = iota
+	SeverityLow
+	SeverityModerate
+	SeverityHigh
+)
+
+// String returns the English name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityNone:
+		return "none"
+	case SeverityLow:
+		return "low"
+	case SeverityModerate:
+		return "moderate"
+	case SeverityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// Language identifies the language of a sky state label.
+type Language string
+
+const (
+	LangEnglish  Language = "en"
+	LangSpanish  Language = "es"
+	LangCatalan  Language = "ca"
+	LangGalician Language = "gl"
+	LangBasque   Language = "eu"
+)
+
+// Languages lists the languages sky state labels are available in.
+var Languages = []Language{LangEnglish, LangSpanish, LangCatalan, LangGalician, LangBasque}
+
+// labels holds a text in every supported language.
+type labels map[Language]string
+
+// SkyState describes an AEMET sky state code such as "11", "43n" or "81".
+type SkyState struct {
+	// Code is the AEMET code, including the "n" suffix for night variants.
+	Code      string
+	Condition Condition
+	Severity  Severity
+	// Night is true for the night variant of the code. It is false for night
+	// codes of states that have no night variant.
+	Night bool
+
+	hasNight bool
+	labels   labels
+}
+
+// Label returns the description of the sky state in the given language.
+// Spanish labels match the descriptions AEMET sends. Unknown languages
+// fall back to Spanish.
+func (s SkyState) Label(lang Language) string {
+	label, ok := s.labels[lang]
+	if !ok {
+		lang = LangSpanish
+		label = s.labels[lang]
+	}
+	if s.Night {
+		label += nightSuffix[lang]
+	}
+	return label
+}
+
+// String returns the Spanish label, as sent by AEMET.
+func (s SkyState) String() string {
+	return s.Label(LangSpanish)
+}
+
+// LookupSkyState returns the catalogue entry for an AEMET sky state code.
+// Night codes of states without a night variant, such as "16n", resolve to
+// the day entry. It reports false for empty or unknown codes.
+func LookupSkyState(code string) (SkyState, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	base, night := strings.CutSuffix(code, "n")
+
+	s, ok := skyStates[base]
+	if !ok {
+		return SkyState{}, false
+	}
+
+	s.Code = code
+	s.Night = night && s.hasNight
+	return s, true
+}
+
+// SkyStates returns the catalogue of day variant sky states, ordered by code.
+func SkyStates() []SkyState {
+	states := make([]SkyState, 0, len(skyStateCodes))
+	for _, code := range skyStateCodes {
+		s := skyStates[code]
+		s.Code = code
+		states = append(states, s)
+	}
+	return states
+}
+
+// SkyState returns the catalogue entry for the sky state code.
+func (e EstadoCielo) SkyState() (SkyState, bool) {
+	return LookupSkyState(e.Value)
+}
+
+// SkyState returns the catalogue entry for the period's sky state code.
+func (f PeriodForecast) SkyState() (SkyState, bool) {
+	return LookupSkyState(f.SkyCode)
+}
+
+var nightSuffix = labels{
+	LangEnglish:  " (night)",
+	LangSpanish:  " noche",
+	LangCatalan:  " (nit)",
+	LangGalician: " (noite)",
+	LangBasque:   " (gaua)",
+}
+
+// Cloud cover labels, shared by the plain and precipitation codes.
+var (
+	coverClear = labels{
+		LangEnglish: "Clear", LangSpanish: "Despejado", LangCatalan: "Serè",
+		LangGalician: "Despexado", LangBasque: "Oskarbi",
+	}
+	coverFew = labels{
+		LangEnglish: "Slightly cloudy", LangSpanish: "Poco nuboso", LangCatalan: "Poc ennuvolat",
+		LangGalician: "Pouco nubrado", LangBasque: "Hodei gutxi",
+	}
+	coverIntervals = labels{
+		LangEnglish: "Cloudy intervals", LangSpanish: "Intervalos nubosos", LangCatalan: "Intervals de núvols",
+		LangGalician: "Intervalos nubrados", LangBasque: "Hodei tarteak",
+	}
+	coverCloudy = labels{
+		LangEnglish: "Cloudy", LangSpanish: "Nuboso", LangCatalan: "Ennuvolat",
+		LangGalician: "Nubrado", LangBasque: "Hodeitsu",
+	}
+	coverVeryCloudy = labels{
+		LangEnglish: "Very cloudy", LangSpanish: "Muy nuboso", LangCatalan: "Molt ennuvolat",
+		LangGalician: "Moi nubrado", LangBasque: "Oso hodeitsu",
+	}
+	coverOvercast = labels{
+		LangEnglish: "Overcast", LangSpanish: "Cubierto", LangCatalan: "Cobert",
+		LangGalician: "Cuberto", LangBasque: "Estalita",
+	}
+	coverHigh = labels{
+		LangEnglish: "High clouds", LangSpanish: "Nubes altas", LangCatalan: "Núvols alts",
+		LangGalician: "Nubes altas", LangBasque: "Goi hodeiak",
+	}
+)
+
+// skyPrecipitation describes a family of codes sharing the same precipitation,
+// e.g. 23-26 are "... con lluvia" with increasing cloud cover.
+type skyPrecipitation struct {
+	first     int
+	condition Condition
+	severity  Severity
+	suffix    labels
+}
+
+var skyPrecipitations = []skyPrecipitation{
+	{23, ConditionRain, SeverityModerate, labels{
+		LangEnglish: " with rain", LangSpanish: " con lluvia", LangCatalan: " amb pluja",
+		LangGalician: " con chuvia", LangBasque: " eta euria",
+	}},
+	{33, ConditionSnow, SeverityHigh, labels{
+		LangEnglish: " with snow", LangSpanish: " con nieve", LangCatalan: " amb neu",
+		LangGalician: " con neve", LangBasque: " eta elurra",
+	}},
+	{43, ConditionLightRain, SeverityLow, labels{
+		LangEnglish: " with light rain", LangSpanish: " con lluvia escasa", LangCatalan: " amb pluja escassa",
+		LangGalician: " con chuvia escasa", LangBasque: " eta euri arina",
+	}},
+	{51, ConditionStorm, SeverityHigh, labels{
+		LangEnglish: " with thunderstorm", LangSpanish: " con tormenta", LangCatalan: " amb tempesta",
+		LangGalician: " con treboada", LangBasque: " eta ekaitza",
+	}},
+	{61, ConditionStorm, SeverityHigh, labels{
+		LangEnglish: " with thunderstorm and light rain", LangSpanish: " con tormenta y lluvia escasa",
+		LangCatalan: " amb tempesta i pluja escassa", LangGalician: " con treboada e chuvia escasa",
+		LangBasque: " eta ekaitza eta euri arina",
+	}},
+	{71, ConditionLightSnow, SeverityModerate, labels{
+		LangEnglish: " with light snow", LangSpanish: " con nieve escasa", LangCatalan: " amb neu escassa",
+		LangGalician: " con neve escasa", LangBasque: " eta elur arina",
+	}},
+}
+
+// skyStateCodes lists every base code in the catalogue, in order.
+var skyStateCodes []string
+
+// skyStates maps base codes (without the night suffix) to their entry.
+var skyStates = buildSkyStates()
+
+func buildSkyStates() map[string]SkyState {
+	states := make(map[string]SkyState)
+	add := func(code string, cond Condition, sev Severity, night bool, l labels) {
+		skyStateCodes = append(skyStateCodes, code)
+		states[code] = SkyState{Condition: cond, Severity: sev, hasNight: night, labels: l}
+	}
+
+	add("11", ConditionClear, SeverityNone, true, coverClear)
+	add("12", ConditionPartlyCloudy, SeverityNone, true, coverFew)
+	add("13", ConditionPartlyCloudy, SeverityNone, true, coverIntervals)
+	add("14", ConditionCloudy, SeverityNone, true, coverCloudy)
+	add("15", ConditionCloudy, SeverityNone, false, coverVeryCloudy)
+	add("16", ConditionOvercast, SeverityNone, false, coverOvercast)
+	add("17", ConditionHighClouds, SeverityNone, true, coverHigh)
+
+	// Each precipitation family has four codes with increasing cloud cover;
+	// only the first two have a night variant.
+	covers := []labels{coverIntervals, coverCloudy, coverVeryCloudy, coverOvercast}
+	for _, p := range skyPrecipitations {
+		for i, cover := range covers {
+			l := make(labels, len(cover))
+			for lang, text := range cover {
+				l[lang] = text + p.suffix[lang]
+			}
+			add(strconv.Itoa(p.first+i), p.condition, p.severity, i < 2, l)
+		}
+	}
+
+	add("81", ConditionFog, SeverityModerate, false, labels{
+		LangEnglish: "Fog", LangSpanish: "Niebla", LangCatalan: "Boira",
+		LangGalician: "Néboa", LangBasque: "Lainoa",
+	})
+	add("82", ConditionMist, SeverityLow, false, labels{
+		LangEnglish: "Mist", LangSpanish: "Bruma", LangCatalan: "Boirina",
+		LangGalician: "Brétema", LangBasque: "Behelainoa",
+	})
+	add("83", ConditionHaze, SeverityLow, false, labels{
+		LangEnglish: "Haze", LangSpanish: "Calima", LangCatalan: "Calitja",
+		LangGalician: "Calima", LangBasque: "Kalima",
+	})
+
+	return states
+}
@@ -0,0 +1,56 @@
+package aemet
+
+import "testing"
+
+func TestLookupSkyState(t *testing.T) {
+	tests := []struct {
+		code      string
+		ok        bool
+		condition Condition
+		night     bool
+		label     string
+	}{
+		{"11", true, ConditionClear, false, "Despejado"},
+		{"12n", true, ConditionPartlyCloudy, true, "Poco nuboso noche"},
+		{" 17N ", true, ConditionHighClouds, true, "Nubes altas noche"},
+		// No night variant: the day entry is used
+		{"15n", true, ConditionCloudy, false, "Muy nuboso"},
+		{"16n", true, ConditionOvercast, false, "Cubierto"},
+		{"25n", true, ConditionRain, false, "Muy nuboso con lluvia"},
+		{"26n", true, ConditionRain, false, "Cubierto con lluvia"},
+		{"81n", true, ConditionFog, false, "Niebla"},
+		{"23n", true, ConditionRain, true, "Intervalos nubosos con lluvia noche"},
+		{"54", true, ConditionStorm, false, "Cubierto con tormenta"},
+		{"83", true, ConditionHaze, false, "Calima"},
+		{"", false, 0, false, ""},
+		{"n", false, 0, false, ""},
+		{"99", false, 0, false, ""},
+	}
+	for _, tt := range tests {
+		s, ok := LookupSkyState(tt.code)
+		if ok != tt.ok {
+			t.Errorf("LookupSkyState(%q) ok = %v, want %v", tt.code, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if s.Condition != tt.condition || s.Night != tt.night || s.String() != tt.label {
+			t.Errorf("LookupSkyState(%q) = %v, night %v, %q; want %v, night %v, %q",
+				tt.code, s.Condition, s.Night, s.String(), tt.condition, tt.night, tt.label)
+		}
+	}
+}
+
+func TestSkyStateLabel(t *testing.T) {
+	s, ok := LookupSkyState("43n")
+	if !ok {
+		t.Fatal("43n not found")
+	}
+	if got := s.Label(LangEnglish); got != "Cloudy intervals with light rain (night)" {
+		t.Errorf("Label(en) = %q", got)
+	}
+	if got := s.Label(Language("xx")); got != "Intervalos nubosos con lluvia escasa noche" {
+		t.Errorf("Label(xx) = %q, want the Spanish label", got)
+	}
+}
@@ -0,0 +1,344 @@
+package aemet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StationType tells synoptic stations from automatic ones
+type StationType int
+
+const (
+	// StationAny matches every station.
+	StationAny StationType = iota
+	// StationSynoptic matches stations with a WMO synoptic index (IndSinop).
+	StationSynoptic
+	// StationAutomatic matches stations without a synoptic index.
+	StationAutomatic
+)
+
+// BoundingBox is an area delimited by decimal degree coordinates
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Contains reports whether the point falls inside the box.
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	return lat >= b.MinLatitude && lat <= b.MaxLatitude && lon >= b.MinLongitude && lon <= b.MaxLongitude
+}
+
+// StationFilter selects weather stations. Zero fields match every station.
+type StationFilter struct {
+	// Provinces the station must belong to.
+	Provinces []Province
+	// Box the station must be located in.
+	Box *BoundingBox
+	// MinAltitude and MaxAltitude bound the station altitude in meters.
+	MinAltitude Int
+	MaxAltitude Int
+	Type        StationType
+}
+
+// Match reports whether the station passes the filter.
+func (f StationFilter) Match(s WeatherStation) bool {
+	switch f.Type {
+	case StationSynoptic:
+		if !s.Synoptic() {
+			return false
+		}
+	case StationAutomatic:
+		if s.Synoptic() {
+			return false
+		}
+	}
+
+	if f.MinAltitude.Valid && (!s.Altitude.Valid || s.Altitude.Value < f.MinAltitude.Value) {
+		return false
+	}
+	if f.MaxAltitude.Valid && (!s.Altitude.Valid || s.Altitude.Value > f.MaxAltitude.Value) {
+		return false
+	}
+
+	if len(f.Provinces) > 0 {
+		p := s.ProvinceCode()
+		found := false
+		for _, fp := range f.Provinces {
+			if fp == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.Box != nil {
+		lat, lon, err := s.Coordinates()
+		if err != nil || !f.Box.Contains(lat, lon) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterStations returns the stations that pass the filter.
+func FilterStations(stations []WeatherStation, f StationFilter) []WeatherStation {
+	var filtered []WeatherStation
+	for _, s := range stations {
+		if f.Match(s) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// FindStations retrieves the weather stations that pass the filter.
+// Stations are filtered as they are decoded, so only matches are kept in memory.
+func (c *Client) FindStations(f StationFilter) ([]WeatherStation, error) {
+	var stations []WeatherStation
+	for s, err := range c.StreamStations() {
+		if err != nil {
+			return nil, err
+		}
+		if f.Match(s) {
+			stations = append(stations, s)
+		}
+	}
+	return stations, nil
+}
+
+// Synoptic reports whether the station has a WMO synoptic index.
+func (s WeatherStation) Synoptic() bool {
+	return strings.TrimSpace(s.IndSinop) != ""
+}
+
+// Coordinates returns the station location in decimal degrees.
+func (s WeatherStation) Coordinates() (lat, lon float64, err error) {
+	if lat, err = parseDMS(s.Latitude); err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude for station %s: %w", s.ID, err)
+	}
+	if lon, err = parseDMS(s.Longitude); err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude for station %s: %w", s.ID, err)
+	}
+	return lat, lon, nil
+}
+
+// parseDMS parses AEMET station coordinates such as "402958N" or "0034041W",
+// that is degrees, minutes and seconds followed by the hemisphere.
+func parseDMS(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 6 {
+		return 0, fmt.Errorf("invalid coordinate: %q", s)
+	}
+
+	digits, hemisphere := s[:len(s)-1], s[len(s)-1]
+	n := len(digits)
+	deg, err1 := strconv.Atoi(digits[:n-4])
+	minutes, err2 := strconv.Atoi(digits[n-4 : n-2])
+	seconds, err3 := strconv.Atoi(digits[n-2:])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, fmt.Errorf("invalid coordinate: %q", s)
+	}
+
+	v := float64(deg) + float64(minutes)/60 + float64(seconds)/3600
+	switch hemisphere {
+	case 'N', 'E':
+	case 'S', 'W':
+		v = -v
+	default:
+		return 0, fmt.Errorf("invalid coordinate hemisphere: %q", s)
+	}
+	return v, nil
+}
+
+// provinceAliases maps older or abbreviated province names used by AEMET
+var provinceAliases = map[string]Province{
+	"ALAVA":                  ProvinceAraba,
+	"BALEARES":               ProvinceIllesBalears,
+	"CASTELLON":              ProvinceCastellon,
+	"CORUNA":                 ProvinceACoruna,
+	"LA CORUNA":              ProvinceACoruna,
+	"GERONA":                 ProvinceGirona,
+	"GUIPUZCOA":              ProvinceGipuzkoa,
+	"LERIDA":                 ProvinceLleida,
+	"ORENSE":                 ProvinceOurense,
+	"VIZCAYA":                ProvinceBizkaia,
+	"STA. CRUZ DE TENERIFE":  ProvinceSantaCruzDeTenerife,
+	"S.C. DE TENERIFE":       ProvinceSantaCruzDeTenerife,
+	"SANTA CRUZ DE TENERIFE": ProvinceSantaCruzDeTenerife,
+}
+
+var accentReplacer = strings.NewReplacer(
+	"Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U", "Ñ", "N",
+	"á", "A", "é", "E", "í", "I", "ó", "O", "ú", "U", "ü", "U", "ñ", "N",
+)
+
+func normalizeName(s string) string {
+	return accentReplacer.Replace(strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// provinceNames maps normalized province names, official or alias, to their province
+var provinceNames = sync.OnceValue(func() map[string]Province {
+	names := make(map[string]Province, len(Provinces)+len(provinceAliases))
+	for _, p := range Provinces {
+		for _, alt := range strings.Split(p.String(), "/") {
+			names[normalizeName(alt)] = p
+		}
+	}
+	for name, p := range provinceAliases {
+		names[name] = p
+	}
+	return names
+})
+
+// ProvinceCode returns the province of the station, from the province name
+// AEMET gives for it. It returns an empty Province if the name is not recognized.
+func (s WeatherStation) ProvinceCode() Province {
+	for _, name := range strings.Split(s.Province, "/") {
+		if p, ok := provinceNames()[normalizeName(name)]; ok {
+			return p
+		}
+	}
+	return ""
+}
+
+// StationInfo is a weather station enriched with its location and nearest municipality
+type StationInfo struct {
+	WeatherStation
+	LatitudeDec  float64
+	LongitudeDec float64
+	// Municipality is the municipality nearest to the station, and Distance
+	// the distance to it in kilometers.
+	Municipality *MunicipalityInfo
+	Distance     float64
+}
+
+// Province returns the province of the station, from its name or, failing
+// that, from its nearest municipality.
+func (s StationInfo) Province() Province {
+	if p := s.ProvinceCode(); p != "" {
+		return p
+	}
+	if s.Municipality != nil {
+		return ProvinceOf(s.Municipality.ID)
+	}
+	return ""
+}
+
+// municipalityPoint is a municipality along with its parsed coordinates
+type municipalityPoint struct {
+	info     *MunicipalityInfo
+	lat, lon float64
+}
+
+// municipalityIndex holds the municipalities with valid coordinates, all
+// together and grouped by province, so coordinates are parsed only once
+type municipalityIndex struct {
+	all        []municipalityPoint
+	byProvince map[Province][]municipalityPoint
+}
+
+var loadMunicipalityIndex = sync.OnceValues(func() (*municipalityIndex, error) {
+	if err := initializeMunicipalities(); err != nil {
+		return nil, err
+	}
+
+	idx := &municipalityIndex{byProvince: make(map[Province][]municipalityPoint)}
+	for _, m := range municipalities {
+		lat, lon, err := m.Coordinates()
+		if err != nil {
+			continue
+		}
+		p := municipalityPoint{info: m, lat: lat, lon: lon}
+		idx.all = append(idx.all, p)
+		province := ProvinceOf(m.ID)
+		idx.byProvince[province] = append(idx.byProvince[province], p)
+	}
+	return idx, nil
+})
+
+// nearest returns the point closest to lat, lon and its distance in kilometers
+func nearest(points []municipalityPoint, lat, lon float64) (*MunicipalityInfo, float64) {
+	var found *MunicipalityInfo
+	best := math.Inf(1)
+	for _, p := range points {
+		if d := distanceKm(lat, lon, p.lat, p.lon); d < best {
+			found, best = p.info, d
+		}
+	}
+	return found, best
+}
+
+// NearestMunicipality returns the municipality closest to the point and its distance in kilometers.
+func NearestMunicipality(lat, lon float64) (*MunicipalityInfo, float64, error) {
+	idx, err := loadMunicipalityIndex()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m, d := nearest(idx.all, lat, lon)
+	if m == nil {
+		return nil, 0, fmt.Errorf("no municipality found near %f,%f", lat, lon)
+	}
+	return m, d, nil
+}
+
+// EnrichStations links every station to its nearest municipality. Stations
+// whose province is recognized are only matched against the municipalities of
+// that province. Stations with invalid coordinates are returned without municipality.
+func EnrichStations(stations []WeatherStation) ([]StationInfo, error) {
+	idx, err := loadMunicipalityIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]StationInfo, 0, len(stations))
+	for _, s := range stations {
+		info := StationInfo{WeatherStation: s}
+		lat, lon, err := s.Coordinates()
+		if err == nil {
+			info.LatitudeDec, info.LongitudeDec = lat, lon
+
+			candidates, ok := idx.byProvince[s.ProvinceCode()]
+			if !ok {
+				candidates = idx.all
+			}
+			info.Municipality, info.Distance = nearest(candidates, lat, lon)
+			if info.Municipality == nil {
+				return nil, fmt.Errorf("no municipality found near station %s", s.ID)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Coordinates returns the municipality location in decimal degrees.
+func (m *MunicipalityInfo) Coordinates() (lat, lon float64, err error) {
+	if lat, err = strconv.ParseFloat(strings.TrimSpace(m.LatitudeDec), 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude for municipality %s: %w", m.ID, err)
+	}
+	if lon, err = strconv.ParseFloat(strings.TrimSpace(m.LongitudeDec), 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude for municipality %s: %w", m.ID, err)
+	}
+	return lat, lon, nil
+}
+
+// distanceKm returns the great-circle distance between two points in kilometers
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371
+	rad := math.Pi / 180
+	dlat := (lat2 - lat1) * rad
+	dlon := (lon2 - lon1) * rad
+	a := math.Sin(dlat/2)*math.Sin(dlat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dlon/2)*math.Sin(dlon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
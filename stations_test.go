@@ -0,0 +1,100 @@
+package aemet
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseDMS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"402443N", 40.411944, true},
+		{"0034041W", -3.678056, true},
+		{"0023903E", 2.650833, true},
+		{"282832N", 28.475556, true},
+		{"335700S", -33.95, true},
+		{" 402443N ", 40.411944, true},
+		{"1234N", 0, false},
+		{"", 0, false},
+		{"40A443N", 0, false},
+		{"402443X", 0, false},
+		{"402443n", 0, false},
+		{"40.4119", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseDMS(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseDMS(%q) error = %v, want ok %v", tt.in, err, tt.ok)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("parseDMS(%q) = %f, want %f", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestProvinceCode(t *testing.T) {
+	tests := []struct {
+		name string
+		want Province
+	}{
+		{"MADRID", ProvinceMadrid},
+		{" Madrid ", ProvinceMadrid},
+		{"A CORUÑA", ProvinceACoruna},
+		{"LA CORUNA", ProvinceACoruna},
+		{"ILLES BALEARS", ProvinceIllesBalears},
+		{"BALEARES", ProvinceIllesBalears},
+		{"ARABA/ALAVA", ProvinceAraba},
+		{"ALAVA", ProvinceAraba},
+		{"VALENCIA", ProvinceValencia},
+		{"STA. CRUZ DE TENERIFE", ProvinceSantaCruzDeTenerife},
+		{"ATLANTIS", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := (WeatherStation{Province: tt.name}).ProvinceCode(); got != tt.want {
+			t.Errorf("ProvinceCode(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnrichStations(t *testing.T) {
+	stations := []WeatherStation{
+		{ID: "3195", Name: "MADRID, RETIRO", Province: "MADRID", Latitude: "402443N", Longitude: "0034041W"},
+		// Madrid coordinates with a province that does not match them: the
+		// nearest municipality is looked up in the station's province
+		{ID: "X1", Province: "TOLEDO", Latitude: "402443N", Longitude: "0034041W"},
+		// Unknown province: every municipality is a candidate
+		{ID: "X2", Province: "DESCONOCIDA", Latitude: "402443N", Longitude: "0034041W"},
+		{ID: "X3", Province: "MADRID", Latitude: "", Longitude: "0034041W"},
+	}
+
+	infos, err := EnrichStations(stations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != len(stations) {
+		t.Fatalf("%d infos, want %d", len(infos), len(stations))
+	}
+
+	if m := infos[0].Municipality; m == nil || m.ID != "28079" || infos[0].Distance > 5 {
+		t.Errorf("Retiro municipality = %+v at %.1f km, want Madrid", m, infos[0].Distance)
+	}
+	if m := infos[1].Municipality; m == nil || ProvinceOf(m.ID) != ProvinceToledo {
+		t.Errorf("Toledo station municipality = %+v, want one in Toledo", m)
+	}
+	if m := infos[2].Municipality; m == nil || m.ID != "28079" {
+		t.Errorf("unknown province municipality = %+v, want Madrid", m)
+	}
+	if infos[3].Municipality != nil || infos[3].Province() != ProvinceMadrid {
+		t.Errorf("station without coordinates = %+v", infos[3])
+	}
+
+	m, d, err := NearestMunicipality(infos[0].LatitudeDec, infos[0].LongitudeDec)
+	if err != nil || m.ID != "28079" || d != infos[0].Distance {
+		t.Errorf("NearestMunicipality = %v, %.2f, %v", m, d, err)
+	}
+}
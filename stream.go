@@ -0,0 +1,79 @@
+package aemet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// streamRedir performs a two-step request to the AEMET API for a JSON array and
+// yields its elements one at a time, so large payloads are decoded with constant
+// memory. Only the requests are retried: once elements have been yielded, an
+// error ends the sequence. A decoding error is yielded once, after which the
+// sequence stops.
+//
+// Streamed payloads are not written to the cache, as that would require reading
+// them whole, but a payload cached by a non-streamed call for the same path is
+// served when the request fails or the client is offline.
+func streamRedir[T any](c *Client, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		// The call ends once the payload is decoded, so hooks see decoding errors
+		ctx, end := c.startCall(context.Background(), path)
+		var err error
+		defer func() { end(err) }()
+
+		var r *http.Response
+		err = c.runCall(ctx, path, maxRetries+1, func(ctx context.Context) error {
+			var err error
+			r, err = c.getDatos(withoutStore(ctx), path)
+			return err
+		})
+		if err != nil {
+			yield(zero, fmt.Errorf("error requesting data: %w", err))
+			return
+		}
+		defer r.Body.Close()
+
+		dec := json.NewDecoder(r.Body)
+		tok, err := dec.Token()
+		if err != nil {
+			err = fmt.Errorf("error decoding data: %w", err)
+			yield(zero, err)
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			err = fmt.Errorf("error decoding data: expected array, got %v", tok)
+			yield(zero, err)
+			return
+		}
+
+		for dec.More() {
+			var v T
+			if err = dec.Decode(&v); err != nil {
+				err = fmt.Errorf("error decoding data: %w", err)
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// StreamStations returns an iterator over all weather stations in the AEMET network.
+// Unlike GetStations, stations are decoded one at a time as they are read.
+//
+//	for station, err := range client.StreamStations() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(station.Name)
+//	}
+func (c *Client) StreamStations() iter.Seq2[WeatherStation, error] {
+	return streamRedir[WeatherStation](c, "api/valores/climatologicos/inventarioestaciones/todasestaciones")
+}
@@ -0,0 +1,99 @@
+package aemet
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// latin9 maps the ISO-8859-15 code points that differ from ISO-8859-1
+var latin9 = map[byte]rune{
+	0xA4: '€',
+	0xA6: 'Š',
+	0xA8: 'š',
+	0xB4: 'Ž',
+	0xB8: 'ž',
+	0xBC: 'Œ',
+	0xBD: 'œ',
+	0xBE: 'Ÿ',
+}
+
+// decodeText returns b as a UTF-8 string.
+// AEMET serves plain text products in ISO-8859-15, so anything that is
+// not valid UTF-8 is decoded as such.
+func decodeText(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		if r, ok := latin9[c]; ok {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
+
+// TextSection represents a section of a plain text AEMET product
+type TextSection struct {
+	Title string
+	Body  string
+}
+
+// isHeading reports whether line looks like a section title: a line with
+// letters, all of them upper case. Upper case "LABEL: value" lines, such as
+// "NAVARRA: LIMITADO (2)", are not titles.
+func isHeading(line string) bool {
+	line = strings.TrimSpace(line)
+	if strings.Contains(line, ": ") {
+		return false
+	}
+	letters := 0
+	for _, r := range line {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 3
+}
+
+// parseTextSections splits a plain text product into sections.
+// Upper case lines start a new section; text before the first of them
+// goes into a section without title. Blank lines inside a body are kept
+// as paragraph breaks.
+func parseTextSections(text string) []TextSection {
+	var sections []TextSection
+	var current *TextSection
+	var body []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Body = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.Title != "" || current.Body != "" {
+			sections = append(sections, *current)
+		}
+		body = nil
+	}
+
+	current = &TextSection{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if isHeading(line) {
+			flush()
+			current = &TextSection{Title: strings.TrimSpace(line)}
+			continue
+		}
+		body = append(body, strings.TrimRight(line, " \t"))
+	}
+	flush()
+
+	return sections
+}
@@ -0,0 +1,90 @@
+package aemet
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TextPeriod is the period covered by a human-written forecast
+type TextPeriod string
+
+const (
+	TextToday            TextPeriod = "hoy"
+	TextTomorrow         TextPeriod = "manana"
+	TextDayAfterTomorrow TextPeriod = "pasadomanana"
+	// TextMediumTerm covers the next days after the day after tomorrow.
+	TextMediumTerm TextPeriod = "medioplazo"
+	// TextTrend covers the trend for the following weeks.
+	TextTrend TextPeriod = "tendencia"
+)
+
+// TextScope identifies the area a human-written forecast covers
+type TextScope string
+
+const (
+	ScopeNational  TextScope = "nacional"
+	ScopeCommunity TextScope = "ccaa"
+	ScopeProvince  TextScope = "provincia"
+)
+
+// textPeriods lists the periods AEMET publishes for each scope
+var textPeriods = map[TextScope][]TextPeriod{
+	ScopeNational:  {TextToday, TextTomorrow, TextDayAfterTomorrow, TextMediumTerm, TextTrend},
+	ScopeCommunity: {TextToday, TextTomorrow, TextDayAfterTomorrow, TextMediumTerm},
+	ScopeProvince:  {TextToday, TextTomorrow},
+}
+
+// TextForecast represents a human-written forecast
+type TextForecast struct {
+	Scope  TextScope
+	Period TextPeriod
+	// Area is the community or province code, empty for national forecasts.
+	Area string
+	// Text is the full forecast, converted to UTF-8.
+	Text     string
+	Sections []TextSection
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus
+}
+
+// GetNationalForecast retrieves the human-written forecast for Spain
+func (c *Client) GetNationalForecast(period TextPeriod) (*TextForecast, error) {
+	return c.getTextForecast(ScopeNational, period, "")
+}
+
+// GetCommunityForecast retrieves the human-written forecast for an autonomous community.
+// Only TextToday, TextTomorrow, TextDayAfterTomorrow and TextMediumTerm are available.
+func (c *Client) GetCommunityForecast(community Community, period TextPeriod) (*TextForecast, error) {
+	return c.getTextForecast(ScopeCommunity, period, string(community))
+}
+
+// GetProvinceForecast retrieves the human-written forecast for a province.
+// Only TextToday and TextTomorrow are available.
+func (c *Client) GetProvinceForecast(province Province, period TextPeriod) (*TextForecast, error) {
+	return c.getTextForecast(ScopeProvince, period, string(province))
+}
+
+func (c *Client) getTextForecast(scope TextScope, period TextPeriod, area string) (*TextForecast, error) {
+	if !slices.Contains(textPeriods[scope], period) {
+		return nil, fmt.Errorf("%s forecast not available for period: %s", scope, period)
+	}
+
+	path := fmt.Sprintf("api/prediccion/%s/%s", scope, period)
+	if area != "" {
+		path += "/" + area
+	}
+
+	text, status, err := c.getRedirTextWithRetry(path)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	return &TextForecast{
+		Scope:    scope,
+		Period:   period,
+		Area:     area,
+		Text:     text,
+		Sections: parseTextSections(text),
+		Cache:    status,
+	}, nil
+}
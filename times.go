@@ -0,0 +1,153 @@
+package aemet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// Embed the timezone database so Europe/Madrid and Atlantic/Canary
+	// resolve on systems without zoneinfo installed.
+	_ "time/tzdata"
+)
+
+// aemetTimeLayout is the layout AEMET uses for timestamps such as
+// "elaborado" and "fecha". Timestamps carry no zone information and are
+// expressed in the local time of the forecast location.
+const aemetTimeLayout = "2006-01-02T15:04:05"
+
+var (
+	madridLocation = sync.OnceValue(func() *time.Location { return loadLocation("Europe/Madrid") })
+	canaryLocation = sync.OnceValue(func() *time.Location { return loadLocation("Atlantic/Canary") })
+)
+
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// MunicipalityLocation returns the time zone used by AEMET for the given municipality ID.
+// Municipalities in Las Palmas (35) and Santa Cruz de Tenerife (38) use Atlantic/Canary,
+// everything else uses Europe/Madrid.
+func MunicipalityLocation(id string) *time.Location {
+	switch ProvinceOf(strings.TrimSpace(id)) {
+	case ProvinceLasPalmas, ProvinceSantaCruzDeTenerife:
+		return canaryLocation()
+	default:
+		return madridLocation()
+	}
+}
+
+// parseAemetTime parses an AEMET timestamp in the given location.
+// An empty string yields the zero time.
+func parseAemetTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(aemetTimeLayout, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing time %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// formatAemetTime formats t using the AEMET timestamp layout.
+// The zero time is formatted as an empty string.
+func formatAemetTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(aemetTimeLayout)
+}
+
+// reanchor returns the same wall clock time as t in loc.
+func reanchor(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// Period represents a forecast period such as "00-12" or "06-12".
+// Start and End are hours of the day, End being exclusive.
+// The zero Period means AEMET did not specify a period and the value
+// applies to the whole day.
+type Period struct {
+	Start int
+	End   int
+}
+
+// ParsePeriod parses an AEMET period string like "00-24" or "06-12".
+// An empty string yields the zero Period.
+func ParsePeriod(s string) (Period, error) {
+	if s == "" {
+		return Period{}, nil
+	}
+
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return Period{}, fmt.Errorf("invalid period: %q", s)
+	}
+
+	var p Period
+	var err error
+	if p.Start, err = strconv.Atoi(start); err != nil {
+		return Period{}, fmt.Errorf("invalid period start: %q", s)
+	}
+	if p.End, err = strconv.Atoi(end); err != nil {
+		return Period{}, fmt.Errorf("invalid period end: %q", s)
+	}
+	if p.Start < 0 || p.End > 24 || p.Start >= p.End {
+		return Period{}, fmt.Errorf("invalid period range: %q", s)
+	}
+
+	return p, nil
+}
+
+// IsZero reports whether the period was left unspecified by AEMET.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// String returns the period in AEMET format, e.g. "06-12".
+func (p Period) String() string {
+	if p.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%02d-%02d", p.Start, p.End)
+}
+
+// Contains reports whether the hour of t, in loc, falls inside the period.
+// AEMET periods are local hours of the municipality, so loc should be its
+// time zone, e.g. the location of Dia.Fecha. The zero Period covers the whole day.
+func (p Period) Contains(t time.Time, loc *time.Location) bool {
+	if p.IsZero() {
+		return true
+	}
+	h := t.In(loc).Hour()
+	return h >= p.Start && h < p.End
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Period) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("error decoding period: %w", err)
+	}
+	parsed, err := ParsePeriod(s)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
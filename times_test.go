@@ -0,0 +1,29 @@
+package aemet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodContains(t *testing.T) {
+	// 10:30 UTC is 12:30 in Madrid and 11:30 in the Canary Islands in summer
+	now := time.Date(2025, 5, 20, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period Period
+		loc    *time.Location
+		want   bool
+	}{
+		{Period{12, 18}, madridLocation(), true},
+		{Period{6, 12}, madridLocation(), false},
+		{Period{6, 12}, canaryLocation(), true},
+		{Period{12, 18}, canaryLocation(), false},
+		{Period{10, 11}, time.UTC, true},
+		{Period{}, madridLocation(), true},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Contains(now, tt.loc); got != tt.want {
+			t.Errorf("%s.Contains(%s, %s) = %v, want %v", tt.period, now, tt.loc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package units
+
+import (
+	"time"
+
+	"github.com/rubiojr/aemet-go"
+)
+
+// Forecast is a municipality forecast with its temperatures, wind speeds,
+// gusts and snow levels expressed in a unit system.
+type Forecast struct {
+	// Municipality is the original forecast, in the units AEMET reports.
+	Municipality *aemet.Municipality
+	System       System
+	Days         []Day
+}
+
+// Day is a day of a Forecast.
+type Day struct {
+	// Dia is the original day, holding the values that need no conversion,
+	// such as probabilities, sky states and relative humidity.
+	Dia    *aemet.Dia
+	System System
+
+	Temperature Temperatures
+	FeelsLike   Temperatures
+}
+
+// Temperatures holds the daily extremes and hourly values of a day.
+// Extremes are nil when AEMET did not provide them, and missing hourly
+// values are left out.
+type Temperatures struct {
+	Max    *Temperature
+	Min    *Temperature
+	Hourly []HourlyTemperature
+}
+
+// HourlyTemperature is a temperature at an hour of the day.
+type HourlyTemperature struct {
+	Hour        int
+	Temperature Temperature
+}
+
+// PeriodForecast is an aemet.PeriodForecast with wind speeds and snow level
+// expressed in a unit system. Quantities are nil when AEMET did not provide them.
+type PeriodForecast struct {
+	Period aemet.Period
+
+	RainProb  aemet.Int
+	StormProb aemet.Int
+	SnowLevel *Length
+
+	SkyCode string
+	SkyDesc string
+
+	WindDir   string
+	WindSpeed *Speed
+	Gust      *Speed
+}
+
+// Convert returns a view of the forecast with temperatures, wind speeds, gusts
+// and snow levels expressed in the units of the given system.
+// The original forecast is left unchanged.
+func Convert(m *aemet.Municipality, sys System) *Forecast {
+	f := &Forecast{Municipality: m, System: sys, Days: make([]Day, len(m.Prediccion.Dia))}
+	for i := range m.Prediccion.Dia {
+		d := &m.Prediccion.Dia[i]
+		f.Days[i] = Day{
+			Dia:         d,
+			System:      sys,
+			Temperature: convertTemperatures(d.Temperatura, sys.Temperature),
+			FeelsLike:   convertTemperatures(d.SensTermica, sys.Temperature),
+		}
+	}
+	return f
+}
+
+// Day returns the forecast for the calendar day of date, see aemet.Municipality.Day.
+func (f *Forecast) Day(date time.Time) (*Day, bool) {
+	dia, ok := f.Municipality.Day(date)
+	if !ok {
+		return nil, false
+	}
+	for i := range f.Days {
+		if f.Days[i].Dia == dia {
+			return &f.Days[i], true
+		}
+	}
+	return nil, false
+}
+
+// Today returns the forecast for the current day in the municipality's time zone.
+func (f *Forecast) Today() (*Day, bool) {
+	return f.Day(time.Now())
+}
+
+// Periods returns the day's forecast per period, see aemet.Dia.Periods.
+func (d *Day) Periods() []PeriodForecast {
+	periods := d.Dia.Periods()
+	out := make([]PeriodForecast, len(periods))
+	for i, p := range periods {
+		out[i] = convertPeriod(p, d.System)
+	}
+	return out
+}
+
+// Period returns the forecast for exactly the given period, see aemet.Dia.Period.
+func (d *Day) Period(p aemet.Period) (PeriodForecast, bool) {
+	f, ok := d.Dia.Period(p)
+	if !ok {
+		return PeriodForecast{}, false
+	}
+	return convertPeriod(f, d.System), true
+}
+
+// At returns the forecast for the given hour of the day, see aemet.Dia.At.
+func (d *Day) At(hour int) (PeriodForecast, bool) {
+	f, ok := d.Dia.At(hour)
+	if !ok {
+		return PeriodForecast{}, false
+	}
+	return convertPeriod(f, d.System), true
+}
+
+func convertPeriod(f aemet.PeriodForecast, sys System) PeriodForecast {
+	return PeriodForecast{
+		Period:    f.Period,
+		RainProb:  f.RainProb,
+		StormProb: f.StormProb,
+		SnowLevel: convertLength(f.SnowLevel, sys.Length),
+		SkyCode:   f.SkyCode,
+		SkyDesc:   f.SkyDesc,
+		WindDir:   f.WindDir,
+		WindSpeed: convertSpeed(f.WindSpeed, sys.Speed),
+		Gust:      convertSpeed(f.Gust, sys.Speed),
+	}
+}
+
+func convertTemperatures(t aemet.Temperatura, u TemperatureUnit) Temperatures {
+	out := Temperatures{
+		Max: convertTemperature(t.Maxima, u),
+		Min: convertTemperature(t.Minima, u),
+	}
+	for _, d := range t.Dato {
+		if v := convertTemperature(d.Value, u); v != nil {
+			out.Hourly = append(out.Hourly, HourlyTemperature{Hour: d.Hora, Temperature: *v})
+		}
+	}
+	return out
+}
+
+func convertTemperature(v aemet.Int, u TemperatureUnit) *Temperature {
+	if !v.Valid {
+		return nil
+	}
+	t := NewTemperature(float64(v.Value), Celsius).To(u)
+	return &t
+}
+
+func convertSpeed(v aemet.Int, u SpeedUnit) *Speed {
+	if !v.Valid {
+		return nil
+	}
+	s := NewSpeed(float64(v.Value), KmPerHour).To(u)
+	return &s
+}
+
+func convertLength(v aemet.Int, u LengthUnit) *Length {
+	if !v.Valid {
+		return nil
+	}
+	l := NewLength(float64(v.Value), Meters).To(u)
+	return &l
+}
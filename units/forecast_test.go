@@ -0,0 +1,123 @@
+package units
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/rubiojr/aemet-go"
+)
+
+func loadForecast(t *testing.T) *aemet.Municipality {
+	t.Helper()
+
+	b, err := os.ReadFile("../testdata/prediccion_diaria_28079.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m []*aemet.Municipality
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m[0]
+}
+
+func TestSpeedTo(t *testing.T) {
+	tests := []struct {
+		kmh  float64
+		unit SpeedUnit
+		want float64
+	}{
+		{5, MetersPerSecond, 1.389},
+		{10, MetersPerSecond, 2.778},
+		{30, Knots, 16.199},
+		{30, MilesPerHour, 18.641},
+		{0, Beaufort, 0},
+		{5, Beaufort, 1},
+		{30, Beaufort, 5},
+		{120, Beaufort, 12},
+	}
+	for _, tt := range tests {
+		got := NewSpeed(tt.kmh, KmPerHour).To(tt.unit)
+		if got.Unit != tt.unit || math.Abs(got.Value-tt.want) > 0.001 {
+			t.Errorf("%v km/h to %s = %v, want %v", tt.kmh, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestBeaufortToKmh(t *testing.T) {
+	tests := []struct {
+		force float64
+		want  float64
+	}{
+		{0, 0},
+		{1, 3.5},
+		{5, 34},
+		{11, 110.5},
+		{12, 118},
+	}
+	for _, tt := range tests {
+		got := NewSpeed(tt.force, Beaufort).To(KmPerHour)
+		if math.Abs(got.Value-tt.want) > 0.001 {
+			t.Errorf("force %v = %v, want %v km/h", tt.force, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	m := loadForecast(t)
+
+	f := Convert(m, SI)
+	day := f.Days[0]
+
+	if day.Temperature.Max == nil || day.Temperature.Max.Unit != Celsius {
+		t.Fatalf("Max = %v, want °C", day.Temperature.Max)
+	}
+
+	p, ok := day.Period(aemet.Period{Start: 6, End: 12})
+	if !ok {
+		t.Fatal("no 06-12 period")
+	}
+	// 5 km/h
+	if p.WindSpeed == nil || p.WindSpeed.String() != "1.4 m/s" {
+		t.Errorf("WindSpeed = %v, want 1.4 m/s", p.WindSpeed)
+	}
+	if p.Gust != nil {
+		t.Errorf("Gust = %v, want missing", p.Gust)
+	}
+	if p.SnowLevel == nil || p.SnowLevel.String() != "2600 m" {
+		t.Errorf("SnowLevel = %v, want 2600 m", p.SnowLevel)
+	}
+
+	imperial := Convert(m, Imperial).Days[0]
+	want := float64(m.Prediccion.Dia[0].Temperatura.Maxima.Value)*9/5 + 32
+	if got := imperial.Temperature.Max; got.Unit != Fahrenheit || got.Value != want {
+		t.Errorf("Max = %v, want %v °F", got, want)
+	}
+	if len(imperial.Temperature.Hourly) != len(m.Prediccion.Dia[0].Temperatura.Dato) {
+		t.Errorf("got %d hourly values, want %d", len(imperial.Temperature.Hourly), len(m.Prediccion.Dia[0].Temperatura.Dato))
+	}
+
+	bft, ok := Convert(m, MetricBeaufort).Days[0].At(20)
+	if !ok || bft.WindSpeed.String() != "Bft 2" || bft.Gust.String() != "Bft 6" {
+		t.Errorf("At(20) in Beaufort = %v, %v", bft.WindSpeed, bft.Gust)
+	}
+
+	// The original forecast is not modified
+	if v := m.Prediccion.Dia[0].Viento[4].Velocidad; v != aemet.NewInt(5) {
+		t.Errorf("original wind speed = %v, want 5", v)
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	for _, s := range Systems {
+		got, err := ParseSystem(s.Name)
+		if err != nil || got != s {
+			t.Errorf("ParseSystem(%q) = %v, %v", s.Name, got, err)
+		}
+	}
+	if _, err := ParseSystem("cubits"); err == nil {
+		t.Error("ParseSystem accepted an unknown system")
+	}
+}
@@ -0,0 +1,269 @@
+// Package units provides typed quantities for the values returned by the AEMET API
+// and conversions between metric, imperial and nautical unit systems.
+//
+// AEMET reports temperatures in degrees Celsius, wind speeds in km/h and
+// heights in meters. Quantities keep track of their unit, so converting is
+// a matter of calling To:
+//
+//	wind := units.NewSpeed(30, units.KmPerHour)
+//	fmt.Println(wind.To(units.Knots)) // 16.2 kn
+//
+// Convert returns a view of a whole aemet.Municipality forecast in a System.
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TemperatureUnit is a unit of temperature.
+type TemperatureUnit int
+
+const (
+	Celsius TemperatureUnit = iota
+	Fahrenheit
+)
+
+// String returns the unit symbol.
+func (u TemperatureUnit) String() string {
+	switch u {
+	case Celsius:
+		return "°C"
+	case Fahrenheit:
+		return "°F"
+	default:
+		return "?"
+	}
+}
+
+// SpeedUnit is a unit of speed.
+type SpeedUnit int
+
+const (
+	KmPerHour SpeedUnit = iota
+	MilesPerHour
+	Knots
+	MetersPerSecond
+	// Beaufort is the Beaufort wind force scale, from 0 (calm) to 12 (hurricane force).
+	Beaufort
+)
+
+// String returns the unit symbol.
+func (u SpeedUnit) String() string {
+	switch u {
+	case KmPerHour:
+		return "km/h"
+	case MilesPerHour:
+		return "mph"
+	case Knots:
+		return "kn"
+	case MetersPerSecond:
+		return "m/s"
+	case Beaufort:
+		return "Bft"
+	default:
+		return "?"
+	}
+}
+
+// LengthUnit is a unit of length, used for heights such as the snow level.
+type LengthUnit int
+
+const (
+	Meters LengthUnit = iota
+	Feet
+)
+
+// String returns the unit symbol.
+func (u LengthUnit) String() string {
+	switch u {
+	case Meters:
+		return "m"
+	case Feet:
+		return "ft"
+	default:
+		return "?"
+	}
+}
+
+// Temperature is a temperature value with its unit.
+type Temperature struct {
+	Value float64
+	Unit  TemperatureUnit
+}
+
+// NewTemperature returns a temperature of v in the given unit.
+func NewTemperature(v float64, u TemperatureUnit) Temperature {
+	return Temperature{Value: v, Unit: u}
+}
+
+// To converts the temperature to the given unit.
+func (t Temperature) To(u TemperatureUnit) Temperature {
+	if t.Unit == u {
+		return t
+	}
+
+	c := t.Value
+	if t.Unit == Fahrenheit {
+		c = (t.Value - 32) * 5 / 9
+	}
+
+	switch u {
+	case Fahrenheit:
+		return Temperature{Value: c*9/5 + 32, Unit: u}
+	default:
+		return Temperature{Value: c, Unit: Celsius}
+	}
+}
+
+// String formats the temperature with one decimal, e.g. "71.6 °F".
+func (t Temperature) String() string {
+	return formatValue(t.Value) + " " + t.Unit.String()
+}
+
+// Speed is a speed value with its unit.
+type Speed struct {
+	Value float64
+	Unit  SpeedUnit
+}
+
+// NewSpeed returns a speed of v in the given unit.
+func NewSpeed(v float64, u SpeedUnit) Speed {
+	return Speed{Value: v, Unit: u}
+}
+
+// kmhPer holds how many km/h one unit of each linear speed unit is.
+var kmhPer = map[SpeedUnit]float64{
+	KmPerHour:       1,
+	MilesPerHour:    1.609344,
+	Knots:           1.852,
+	MetersPerSecond: 3.6,
+}
+
+// beaufortLimits holds the upper bound in km/h of Beaufort forces 0 to 11.
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+// kmh returns the speed in km/h. Beaufort forces 1 to 11 are converted to the
+// middle of their range. Force 0 is calm, 0 km/h, and force 12, which has no
+// upper bound, converts to its lower bound of 118 km/h.
+func (s Speed) kmh() float64 {
+	if s.Unit != Beaufort {
+		return s.Value * kmhPer[s.Unit]
+	}
+
+	force := int(s.Value)
+	switch {
+	case force <= 0:
+		return 0
+	case force >= len(beaufortLimits):
+		return beaufortLimits[len(beaufortLimits)-1]
+	default:
+		return (beaufortLimits[force-1] + beaufortLimits[force]) / 2
+	}
+}
+
+// To converts the speed to the given unit.
+// Converting to Beaufort yields the integer force for the speed.
+func (s Speed) To(u SpeedUnit) Speed {
+	if s.Unit == u {
+		return s
+	}
+
+	kmh := s.kmh()
+	if u == Beaufort {
+		force := len(beaufortLimits)
+		for i, limit := range beaufortLimits {
+			if kmh < limit {
+				force = i
+				break
+			}
+		}
+		return Speed{Value: float64(force), Unit: Beaufort}
+	}
+
+	return Speed{Value: kmh / kmhPer[u], Unit: u}
+}
+
+// String formats the speed with one decimal, e.g. "16.2 kn".
+// Beaufort forces are formatted as "Bft 4".
+func (s Speed) String() string {
+	if s.Unit == Beaufort {
+		return fmt.Sprintf("%s %d", s.Unit, int(s.Value))
+	}
+	return formatValue(s.Value) + " " + s.Unit.String()
+}
+
+// Length is a length value with its unit.
+type Length struct {
+	Value float64
+	Unit  LengthUnit
+}
+
+// NewLength returns a length of v in the given unit.
+func NewLength(v float64, u LengthUnit) Length {
+	return Length{Value: v, Unit: u}
+}
+
+// To converts the length to the given unit.
+func (l Length) To(u LengthUnit) Length {
+	if l.Unit == u {
+		return l
+	}
+
+	m := l.Value
+	if l.Unit == Feet {
+		m = l.Value * 0.3048
+	}
+
+	switch u {
+	case Feet:
+		return Length{Value: m / 0.3048, Unit: u}
+	default:
+		return Length{Value: m, Unit: Meters}
+	}
+}
+
+// String formats the length with one decimal, e.g. "7874 ft".
+func (l Length) String() string {
+	return formatValue(l.Value) + " " + l.Unit.String()
+}
+
+func formatValue(v float64) string {
+	return strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0")
+}
+
+// System is a set of units used to present a forecast.
+type System struct {
+	Name        string
+	Temperature TemperatureUnit
+	Speed       SpeedUnit
+	Length      LengthUnit
+}
+
+var (
+	// Metric uses the units AEMET reports in: °C, km/h and meters.
+	Metric = System{Name: "metric", Temperature: Celsius, Speed: KmPerHour, Length: Meters}
+	// SI uses °C, m/s and meters.
+	SI = System{Name: "si", Temperature: Celsius, Speed: MetersPerSecond, Length: Meters}
+	// Imperial uses °F, mph and feet.
+	Imperial = System{Name: "imperial", Temperature: Fahrenheit, Speed: MilesPerHour, Length: Feet}
+	// Nautical uses °C, knots and meters.
+	Nautical = System{Name: "nautical", Temperature: Celsius, Speed: Knots, Length: Meters}
+	// MetricBeaufort uses °C, the Beaufort scale and meters.
+	MetricBeaufort = System{Name: "beaufort", Temperature: Celsius, Speed: Beaufort, Length: Meters}
+)
+
+// Systems lists the predefined unit systems.
+var Systems = []System{Metric, SI, Imperial, Nautical, MetricBeaufort}
+
+// ParseSystem returns the predefined unit system with the given name.
+func ParseSystem(name string) (System, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, s := range Systems {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return System{}, fmt.Errorf("unknown unit system: %s", name)
+}
@@ -0,0 +1,157 @@
+package aemet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// UVRecord represents the maximum UV index forecast for a location
+type UVRecord struct {
+	Location string
+	Index    Int
+}
+
+// UVForecast represents the UV index forecast for all capitals on a given day
+type UVForecast struct {
+	// Day is the forecast day, 0 for today up to 4.
+	Day  int
+	Date time.Time
+	// Records holds one entry per location, in the order AEMET lists them.
+	Records []UVRecord
+	// Cache tells whether the forecast was served from the client cache.
+	Cache CacheStatus
+}
+
+// UVMunicipality is a UV record joined with its municipality.
+// Municipality is nil when no municipality matches the record's location.
+type UVMunicipality struct {
+	UVRecord
+	Municipality *MunicipalityInfo
+}
+
+// Get returns the record for the given location, matched case-insensitively.
+func (f *UVForecast) Get(location string) (UVRecord, bool) {
+	location = strings.ToLower(strings.TrimSpace(location))
+	for _, r := range f.Records {
+		if strings.ToLower(r.Location) == location {
+			return r, true
+		}
+	}
+	return UVRecord{}, false
+}
+
+// ByLocation returns the records keyed by location name.
+func (f *UVForecast) ByLocation() map[string]UVRecord {
+	records := make(map[string]UVRecord, len(f.Records))
+	for _, r := range f.Records {
+		records[r.Location] = r
+	}
+	return records
+}
+
+// Join returns every record along with its municipality.
+func (f *UVForecast) Join() []UVMunicipality {
+	joined := make([]UVMunicipality, 0, len(f.Records))
+	for _, r := range f.Records {
+		m, _ := r.MunicipalityInfo()
+		joined = append(joined, UVMunicipality{UVRecord: r, Municipality: m})
+	}
+	return joined
+}
+
+// MunicipalityInfo returns the municipality matching the record's location.
+// AEMET names capitals like "A Coruña", while the municipality catalogue
+// uses "Coruña, A"; both forms are tried.
+func (r UVRecord) MunicipalityInfo() (*MunicipalityInfo, error) {
+	candidates := []string{r.Location}
+	if article, name, ok := strings.Cut(r.Location, " "); ok && isArticle(article) {
+		candidates = append(candidates, name+", "+article)
+	}
+
+	for _, name := range candidates {
+		if id, err := FindMunicipalityID(name); err == nil {
+			return GetMunicipalityByID(id)
+		}
+	}
+
+	return nil, fmt.Errorf("municipality not found: %s", r.Location)
+}
+
+func isArticle(s string) bool {
+	switch strings.ToLower(s) {
+	case "a", "o", "as", "os", "el", "la", "los", "las", "l'", "els", "les":
+		return true
+	}
+	return false
+}
+
+// parseUVForecast parses the CSV-like UVI payload, a list of quoted location
+// names and index values separated by commas or semicolons, with an optional header.
+func parseUVForecast(text string) ([]UVRecord, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+
+	first, _, _ := strings.Cut(text, "\n")
+	r := csv.NewReader(strings.NewReader(text))
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	if strings.Count(first, ";") > strings.Count(first, ",") {
+		r.Comma = ';'
+	}
+
+	var records []UVRecord
+	for line := 1; ; line++ {
+		fields, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing UV data: %w", err)
+		}
+		if len(fields) < 2 {
+			continue
+		}
+
+		index, err := parseInt(fields[1])
+		if err != nil {
+			if line == 1 {
+				// Header row
+				continue
+			}
+			return nil, fmt.Errorf("error parsing UV index for %s: %w", fields[0], err)
+		}
+
+		records = append(records, UVRecord{
+			Location: strings.TrimSpace(fields[0]),
+			Index:    index,
+		})
+	}
+
+	return records, nil
+}
+
+// GetUVForecast retrieves the maximum UV index forecast for all provincial capitals,
+// where day is 0 for today up to 4 for four days ahead.
+func (c *Client) GetUVForecast(day int) (*UVForecast, error) {
+	if day < 0 || day > 4 {
+		return nil, fmt.Errorf("invalid UV forecast day: %d", day)
+	}
+
+	text, status, err := c.getRedirTextWithRetry(fmt.Sprintf("api/prediccion/especifica/uvi/%d", day))
+	if err != nil {
+		return nil, fmt.Errorf("error requesting data: %w", err)
+	}
+
+	records, err := parseUVForecast(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UVForecast{Day: day, Date: retrievedDay(status, day), Records: records, Cache: status}, nil
+}
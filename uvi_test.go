@@ -0,0 +1,90 @@
+package aemet
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseUVForecast(t *testing.T) {
+	// Payloads follow the layout of the UVI product, a quoted location name
+	// and the index per line. They are not downloads from opendata.aemet.es.
+	tests := []struct {
+		name string
+		text string
+		want []UVRecord
+	}{
+		{
+			name: "quoted with header",
+			text: "\"ciudad\",\"uvi\"\r\n\"A Coruña\",6\r\n\"Albacete\",8\r\n\"Santa Cruz de Tenerife\",11\r\n",
+			want: []UVRecord{
+				{"A Coruña", NewInt(6)},
+				{"Albacete", NewInt(8)},
+				{"Santa Cruz de Tenerife", NewInt(11)},
+			},
+		},
+		{
+			name: "semicolons without header",
+			text: "\"Madrid\";9\n\"Palma\";\"8\"\n",
+			want: []UVRecord{{"Madrid", NewInt(9)}, {"Palma", NewInt(8)}},
+		},
+		{
+			name: "missing index",
+			text: "\"ciudad\",\"uvi\"\n\"Melilla\",\n\"Ceuta\", 9\n",
+			want: []UVRecord{{"Melilla", Int{}}, {"Ceuta", NewInt(9)}},
+		},
+		{
+			name: "comma in name",
+			text: "\"ciudad\",\"uvi\"\n\"Palmas de Gran Canaria, Las\",10\n",
+			want: []UVRecord{{"Palmas de Gran Canaria, Las", NewInt(10)}},
+		},
+		{
+			name: "single field lines",
+			text: "Predicción de UVI máximo\n\"Bilbao\",5\n",
+			want: []UVRecord{{"Bilbao", NewInt(5)}},
+		},
+		{
+			name: "empty",
+			text: " \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUVForecast(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("records = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUVForecastErrors(t *testing.T) {
+	if _, err := parseUVForecast("\"ciudad\",\"uvi\"\n\"Madrid\",alto\n"); err == nil {
+		t.Error("parsed a non-numeric index")
+	}
+}
+
+func TestUVForecastGet(t *testing.T) {
+	records, err := parseUVForecast("\"ciudad\",\"uvi\"\n\"A Coruña\",6\n\"Madrid\",9\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &UVForecast{Records: records}
+
+	if r, ok := f.Get(" madrid "); !ok || r.Index != NewInt(9) {
+		t.Errorf("Get(madrid) = %+v, %v", r, ok)
+	}
+	if _, ok := f.Get("Lugo"); ok {
+		t.Error("Get(Lugo) found a record")
+	}
+
+	m, err := records[0].MunicipalityInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "15030" {
+		t.Errorf("A Coruña municipality = %s (%s), want 15030", m.ID, m.Name)
+	}
+}